@@ -0,0 +1,79 @@
+package gomal
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// coerce replaces a string value, or a non-nil pointer to one, with what parse
+// returns for the following rules. When parse fails, it records a violation of
+// rule and skips the remaining rules. Values of other kinds and nil pointers
+// are left alone.
+func (validator Validator) coerce(rule string, params map[string]any, parse func(value string) (any, bool), option []ValidatorOption) Validator {
+	if validator.stop {
+		return validator
+	}
+
+	value := validator.reflectValue
+	for value.Kind() == reflect.Pointer && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.String {
+		return validator
+	}
+	parsed, ok := parse(value.String())
+	if !ok {
+		validator.addViolation(rule, params, "", option)
+		return validator.When(false)
+	}
+	validator.setValue(parsed)
+	return validator
+}
+
+// AsInt parses a string, such as a query parameter, into an int for the
+// following rules:
+//
+//	gomal.If("page", r.URL.Query().Get("page")).AsInt().Between(1, 100)
+//
+// A string that is not a whole number fails and skips the remaining rules.
+func (validator Validator) AsInt(option ...ValidatorOption) Validator {
+	return validator.coerce("int", nil, func(value string) (any, bool) {
+		parsed, err := strconv.Atoi(value)
+		return parsed, err == nil
+	}, option)
+}
+
+// AsFloat parses a string into a float64, see AsInt. NaN and infinities fail.
+func (validator Validator) AsFloat(option ...ValidatorOption) Validator {
+	return validator.coerce("float", nil, func(value string) (any, bool) {
+		parsed, err := strconv.ParseFloat(value, 64)
+		return parsed, err == nil && !math.IsNaN(parsed) && !math.IsInf(parsed, 0)
+	}, option)
+}
+
+// AsBool parses a string into a bool with strconv.ParseBool, see AsInt.
+func (validator Validator) AsBool(option ...ValidatorOption) Validator {
+	return validator.coerce("bool", nil, func(value string) (any, bool) {
+		parsed, err := strconv.ParseBool(value)
+		return parsed, err == nil
+	}, option)
+}
+
+// AsDuration parses a string such as "1h30m" into a time.Duration, see AsInt.
+func (validator Validator) AsDuration(option ...ValidatorOption) Validator {
+	return validator.coerce("duration", nil, func(value string) (any, bool) {
+		parsed, err := time.ParseDuration(value)
+		return parsed, err == nil
+	}, option)
+}
+
+// AsTime parses a string into a time.Time with layout, such as time.RFC3339 or
+// time.DateOnly, see AsInt.
+func (validator Validator) AsTime(layout string, option ...ValidatorOption) Validator {
+	return validator.coerce("time", map[string]any{"layout": layout}, func(value string) (any, bool) {
+		parsed, err := time.Parse(layout, value)
+		return parsed, err == nil
+	}, option)
+}
@@ -0,0 +1,89 @@
+package gomal_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ItsMalma/gomal"
+)
+
+func TestCoercion(t *testing.T) {
+	tests := []struct {
+		name      string
+		validator gomal.Validator
+		value     any
+		results   []gomal.ValidationResult
+	}{
+		{
+			name:      "int",
+			validator: gomal.If("page", "120").AsInt().Between(1, 100),
+			value:     120,
+			results:   []gomal.ValidationResult{{Name: "page", Messages: []string{"page must be between 1 and 100."}}},
+		},
+		{
+			name:      "pointer to a string",
+			validator: gomal.If("page", stringPointer("120")).AsInt().Between(1, 100),
+			value:     120,
+			results:   []gomal.ValidationResult{{Name: "page", Messages: []string{"page must be between 1 and 100."}}},
+		},
+		{
+			name:      "nil pointer is left alone",
+			validator: gomal.If("page", (*string)(nil)).AsInt(),
+			value:     (*string)(nil),
+			results:   []gomal.ValidationResult{},
+		},
+		{
+			name:      "not an int stops the chain",
+			validator: gomal.If("page", "1.5").AsInt().Between(1, 100),
+			value:     "1.5",
+			results:   []gomal.ValidationResult{{Name: "page", Messages: []string{"page must be a whole number."}}},
+		},
+		{
+			name:      "float",
+			validator: gomal.If("ratio", "NaN").AsFloat().Finite(),
+			value:     "NaN",
+			results:   []gomal.ValidationResult{{Name: "ratio", Messages: []string{"ratio must be a number."}}},
+		},
+		{
+			name:      "bool",
+			validator: gomal.If("subscribe", "true").AsBool().Equal(true),
+			value:     true,
+			results:   []gomal.ValidationResult{},
+		},
+		{
+			name:      "duration",
+			validator: gomal.If("timeout", "90s").AsDuration().LessThanOrEqual(time.Minute),
+			value:     90 * time.Second,
+			results:   []gomal.ValidationResult{{Name: "timeout", Messages: []string{"timeout must be less than or equal to 1 minute."}}},
+		},
+		{
+			name:      "time",
+			validator: gomal.If("date", "01/05/2024").AsTime(time.DateOnly).NotInPast(),
+			value:     "01/05/2024",
+			results:   []gomal.ValidationResult{{Name: "date", Messages: []string{"date must be a time in the 2006-01-02 format."}}},
+		},
+		{
+			name:      "not a string",
+			validator: gomal.If("page", 3).AsInt(),
+			value:     3,
+			results:   []gomal.ValidationResult{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			if value := test.validator.Value(); !reflect.DeepEqual(value, test.value) {
+				tt.Fatalf("expected %#v but got %#v instead", test.value, value)
+			}
+			results := gomal.Validate(test.validator)
+			if !reflect.DeepEqual(results, test.results) {
+				tt.Fatalf("expected %#v but got %#v instead", test.results, results)
+			}
+		})
+	}
+}
+
+func stringPointer(value string) *string {
+	return &value
+}
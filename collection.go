@@ -0,0 +1,185 @@
+package gomal
+
+import (
+	"reflect"
+	"strings"
+)
+
+// itemCount returns the number of elements of a slice, array or map, following
+// pointers. A nil slice or map has none.
+func itemCount(value reflect.Value) (int, bool) {
+	value = indirect(value)
+	switch value.Kind() {
+	case reflect.Array, reflect.Slice, reflect.Map:
+		return value.Len(), true
+	}
+	return 0, false
+}
+
+// MinItems checks that a slice, array or map has at least min elements.
+func (validator Validator) MinItems(min int, option ...ValidatorOption) Validator {
+	if validator.stop {
+		return validator
+	}
+
+	if count, ok := itemCount(validator.reflectValue); ok && count < min {
+		validator.addViolation("minitems", map[string]any{"min": min, "actual": count}, "", option)
+	}
+	return validator
+}
+
+// MaxItems checks that a slice, array or map has at most max elements.
+func (validator Validator) MaxItems(max int, option ...ValidatorOption) Validator {
+	if validator.stop {
+		return validator
+	}
+
+	if count, ok := itemCount(validator.reflectValue); ok && count > max {
+		validator.addViolation("maxitems", map[string]any{"max": max, "actual": count}, "", option)
+	}
+	return validator
+}
+
+// Unique checks that the elements of a slice or array are distinct. When key is
+// not nil, elements are compared by what it returns, e.g. the ID of a struct.
+func (validator Validator) Unique(key func(item any) any, option ...ValidatorOption) Validator {
+	if validator.stop {
+		return validator
+	}
+
+	value := indirect(validator.reflectValue)
+	if value.Kind() != reflect.Array && value.Kind() != reflect.Slice {
+		return validator
+	}
+	// Items whose equality == decides are looked up in a set, the others are
+	// compared with reflect.DeepEqual.
+	seen := map[any]struct{}{}
+	others := []any{}
+	hashable := map[reflect.Type]bool{}
+	for i := 0; i < value.Len(); i++ {
+		item := value.Index(i).Interface()
+		if key != nil {
+			item = key(item)
+		}
+		itemType := reflect.TypeOf(item)
+		isHashable, ok := hashable[itemType]
+		if !ok {
+			isHashable = itemType != nil && isHashableType(itemType)
+			hashable[itemType] = isHashable
+		}
+
+		duplicate := false
+		if isHashable {
+			_, duplicate = seen[item]
+			seen[item] = struct{}{}
+		} else {
+			duplicate = containsValue(others, item)
+			others = append(others, item)
+		}
+		if duplicate {
+			validator.addViolation("unique", map[string]any{"duplicate": item}, "", option)
+			return validator
+		}
+	}
+	return validator
+}
+
+// isHashableType reports whether values of valueType are equal with == exactly
+// when they are with reflect.DeepEqual: booleans, numbers and strings, and
+// arrays and structs made of them.
+func isHashableType(valueType reflect.Type) bool {
+	switch valueType.Kind() {
+	case reflect.Bool, reflect.String, reflect.Complex64, reflect.Complex128:
+		return true
+	case reflect.Array:
+		return isHashableType(valueType.Elem())
+	case reflect.Struct:
+		for i := 0; i < valueType.NumField(); i++ {
+			if !isHashableType(valueType.Field(i).Type) {
+				return false
+			}
+		}
+		return true
+	}
+	return isNumber(valueType.Kind())
+}
+
+// Contains checks that a slice or array has an element equal to element, or
+// that a string contains element as a substring.
+func (validator Validator) Contains(element any, option ...ValidatorOption) Validator {
+	if validator.stop {
+		return validator
+	}
+
+	value := indirect(validator.reflectValue)
+	found := true
+	switch value.Kind() {
+	case reflect.String:
+		substring, ok := element.(string)
+		found = ok && strings.Contains(value.String(), substring)
+	case reflect.Array, reflect.Slice:
+		found = false
+		for i := 0; i < value.Len() && !found; i++ {
+			found = sameValue(value.Index(i), element)
+		}
+	}
+	if !found {
+		validator.addViolation("contains", map[string]any{"value": element}, "", option)
+	}
+	return validator
+}
+
+// Subset checks that every element of a slice or array is one of values.
+func (validator Validator) Subset(values []any, option ...ValidatorOption) Validator {
+	if validator.stop {
+		return validator
+	}
+
+	value := indirect(validator.reflectValue)
+	if value.Kind() != reflect.Array && value.Kind() != reflect.Slice {
+		return validator
+	}
+	for i := 0; i < value.Len(); i++ {
+		allowed := false
+		for _, another := range values {
+			if sameValue(value.Index(i), another) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			validator.addViolation("subset", map[string]any{"values": values}, "", option)
+			break
+		}
+	}
+	return validator
+}
+
+// Each applies rules to every element of a slice, array or map, reported as
+// "name[index]" or "name[key]", see Dive.
+func (validator Validator) Each(rules ...Rule) Validator {
+	return validator.Dive(func(item Validator) Validator {
+		return applyRules(item, rules)
+	})
+}
+
+// Values applies rules to every value of a map, see Each.
+func (validator Validator) Values(rules ...Rule) Validator {
+	return validator.Each(rules...)
+}
+
+// Keys applies rules to every key of a map. A key is reported with the path of
+// its value, e.g. headers["X-Id"].
+func (validator Validator) Keys(rules ...Rule) Validator {
+	if validator.stop {
+		return validator
+	}
+
+	value := indirect(validator.reflectValue)
+	if value.Kind() == reflect.Map {
+		for _, key := range sortedMapKeys(value) {
+			validator.children = append(validator.children, applyRules(If(keyPath(validator.name, key), key.Interface()), rules))
+		}
+	}
+	return validator
+}
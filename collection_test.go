@@ -0,0 +1,117 @@
+package gomal_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ItsMalma/gomal"
+)
+
+func TestCollectionRules(t *testing.T) {
+	type item struct {
+		SKU string
+	}
+
+	tests := []struct {
+		name      string
+		validator gomal.Validator
+		results   []gomal.ValidationResult
+	}{
+		{
+			name:      "min items",
+			validator: gomal.If("tags", []string(nil)).MinItems(1),
+			results:   []gomal.ValidationResult{{Name: "tags", Messages: []string{"tags must contain at least 1 items. It contains 0 items."}}},
+		},
+		{
+			name:      "max items of map",
+			validator: gomal.If("headers", map[string]string{"a": "1", "b": "2"}).MaxItems(1),
+			results:   []gomal.ValidationResult{{Name: "headers", Messages: []string{"headers must contain 1 items or fewer. It contains 2 items."}}},
+		},
+		{
+			name:      "unique",
+			validator: gomal.If("tags", []string{"go", "rust", "go"}).Unique(nil),
+			results:   []gomal.ValidationResult{{Name: "tags", Messages: []string{"tags must not contain duplicates of go."}}},
+		},
+		{
+			name:      "unique by key",
+			validator: gomal.If("items", []item{{SKU: "A"}, {SKU: "B"}}).Unique(func(i any) any { return i.(item).SKU }),
+			results:   []gomal.ValidationResult{},
+		},
+		{
+			name:      "unique of incomparable items",
+			validator: gomal.If("ranges", [][]int{{1, 2}, {3}, {1, 2}}).Unique(nil),
+			results:   []gomal.ValidationResult{{Name: "ranges", Messages: []string{"ranges must not contain duplicates of [1 2]."}}},
+		},
+		{
+			name:      "unique of mixed items",
+			validator: gomal.If("values", []any{1, "1", []int{1}, 1.0, int64(1)}).Unique(nil),
+			results:   []gomal.ValidationResult{},
+		},
+		{
+			name:      "contains",
+			validator: gomal.If("roles", []string{"member"}).Contains("admin"),
+			results:   []gomal.ValidationResult{{Name: "roles", Messages: []string{"roles must contain admin."}}},
+		},
+		{
+			name:      "subset",
+			validator: gomal.If("sizes", []string{"S", "XXL"}).Subset([]any{"S", "M", "L"}),
+			results:   []gomal.ValidationResult{{Name: "sizes", Messages: []string{"sizes may only contain S, M, L."}}},
+		},
+		{
+			name:      "each",
+			validator: gomal.If("tags", []string{"go", ""}).Each(gomal.Rules.NotEmpty(), gomal.Rules.MaxLength(4)),
+			results:   []gomal.ValidationResult{{Name: "tags[1]", Messages: []string{"tags[1] should not be empty."}}},
+		},
+		{
+			name: "keys and values",
+			validator: gomal.If("headers", map[string]string{"X-Id": "", "x": "1"}).
+				Keys(gomal.Rules.MinLength(2)).
+				Values(gomal.Rules.NotEmpty()),
+			results: []gomal.ValidationResult{
+				{Name: `headers["x"]`, Messages: []string{`The length of headers["x"] must be at least 2 characters. You entered 1 characters.`}},
+				{Name: `headers["X-Id"]`, Messages: []string{`headers["X-Id"] should not be empty.`}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			results := gomal.Validate(test.validator)
+			if !reflect.DeepEqual(results, test.results) {
+				tt.Fatalf("expected %#v but got %#v instead", test.results, results)
+			}
+		})
+	}
+}
+
+func TestCollectionTags(t *testing.T) {
+	type product struct {
+		Sizes []string `json:"sizes" gomal:"minitems=1,unique,subset=S|M|L"`
+		IDs   []int    `json:"ids" gomal:"contains=1"`
+	}
+
+	expected := []gomal.ValidationResult{
+		{Name: "sizes", Messages: []string{"sizes must not contain duplicates of M.", "sizes may only contain S, M, L."}},
+		{Name: "ids", Messages: []string{"ids must contain 1."}},
+	}
+	results := gomal.ValidateStruct(product{Sizes: []string{"M", "M", "XL"}, IDs: []int{2}})
+	if !reflect.DeepEqual(results, expected) {
+		t.Fatalf("expected %#v but got %#v instead", expected, results)
+	}
+}
+
+func TestUniqueLarge(t *testing.T) {
+	ids := make([]int, 200000)
+	for i := range ids {
+		ids[i] = i
+	}
+	if results := gomal.Validate(gomal.If("ids", ids).Unique(nil)); len(results) != 0 {
+		t.Fatalf("expected no results but got %#v instead", results)
+	}
+
+	ids[len(ids)-1] = 0
+	expected := []gomal.ValidationResult{{Name: "ids", Messages: []string{"ids must not contain duplicates of 0."}}}
+	if results := gomal.Validate(gomal.If("ids", ids).Unique(nil)); !reflect.DeepEqual(results, expected) {
+		t.Fatalf("expected %#v but got %#v instead", expected, results)
+	}
+}
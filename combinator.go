@@ -0,0 +1,151 @@
+package gomal
+
+import "context"
+
+// probe applies rules in order to a copy of validator and returns the copy,
+// holding only what the rules recorded.
+func (validator Validator) probe(rules ...Rule) Validator {
+	validator.violations = []Violation{}
+	validator.children = []Validator{}
+	validator.deferred = nil
+	validator.invariants = false
+	return applyRules(validator, rules)
+}
+
+// pending reports whether validator or one of its children holds context-aware
+// rules, which run when it is resolved.
+func pending(validator Validator) bool {
+	if len(validator.deferred) > 0 {
+		return true
+	}
+	for _, child := range validator.children {
+		if pending(child) {
+			return true
+		}
+	}
+	return false
+}
+
+// collectViolations appends the violations of validator and its children,
+// leaving their messages to be rendered.
+func collectViolations(violations []Violation, validator Validator) []Violation {
+	violations = append(violations, validator.violations...)
+	for _, child := range validator.children {
+		violations = collectViolations(violations, child)
+	}
+	return violations
+}
+
+// combine passes the violations of the probed validators to decide, which
+// returns the params of the violation of rule to record, or false when the
+// combinator passes. When a probed rule needs a context, such as IsCtx, the
+// decision waits until the validator is resolved.
+func (validator Validator) combine(rule string, probed []Validator, decide func(failed [][]Violation) (map[string]any, bool), option []ValidatorOption) Validator {
+	deferred := false
+	for _, probe := range probed {
+		deferred = deferred || pending(probe)
+	}
+
+	record := func(ctx context.Context, validator Validator) (Validator, error) {
+		failed := make([][]Violation, len(probed))
+		for i, probe := range probed {
+			resolved, err := probe.resolve(ctx)
+			if err != nil {
+				return validator, err
+			}
+			failed[i] = collectViolations([]Violation{}, resolved)
+		}
+		if params, ok := decide(failed); ok {
+			validator.addViolation(rule, params, "", option)
+		}
+		return validator, nil
+	}
+
+	if !deferred {
+		validator, _ = record(context.Background(), validator)
+		return validator
+	}
+	validator.deferred = append(validator.deferred[:len(validator.deferred):len(validator.deferred)], deferredRule{
+		value:  validator.value,
+		option: option,
+		check: func(ctx context.Context) ([]Violation, error) {
+			recorded, err := record(ctx, validator.probe())
+			return recorded.violations, err
+		},
+	})
+	return validator
+}
+
+// AnyOf checks that at least one of rules passes, e.g. "an email address or a
+// phone number". Otherwise it records a single violation whose message lists
+// the messages of every rule.
+func (validator Validator) AnyOf(rules []Rule, option ...ValidatorOption) Validator {
+	if validator.stop {
+		return validator
+	}
+
+	probed := make([]Validator, len(rules))
+	for i, rule := range rules {
+		probed[i] = validator.probe(rule)
+	}
+	return validator.combine("anyof", probed, func(failed [][]Violation) (map[string]any, bool) {
+		all := []Violation{}
+		for _, violations := range failed {
+			if len(violations) == 0 {
+				return nil, false
+			}
+			all = append(all, violations...)
+		}
+		return map[string]any{"violations": all}, true
+	}, option)
+}
+
+// AllOf checks that every rule passes, recording a single violation that lists
+// the messages of the failed ones. The rules are applied in order, so a
+// transformer such as Trim changes the value for the following ones.
+func (validator Validator) AllOf(rules []Rule, option ...ValidatorOption) Validator {
+	if validator.stop {
+		return validator
+	}
+
+	return validator.combine("allof", []Validator{validator.probe(rules...)}, func(failed [][]Violation) (map[string]any, bool) {
+		if len(failed[0]) == 0 {
+			return nil, false
+		}
+		return map[string]any{"violations": failed[0]}, true
+	}, option)
+}
+
+// Not checks that rule fails.
+func (validator Validator) Not(rule Rule, option ...ValidatorOption) Validator {
+	if validator.stop {
+		return validator
+	}
+
+	return validator.combine("not", []Validator{validator.probe(rule)}, func(failed [][]Violation) (map[string]any, bool) {
+		return map[string]any{"rule": rule.Code()}, len(failed[0]) == 0
+	}, option)
+}
+
+// ExactlyOneOf checks that exactly one of rules passes.
+func (validator Validator) ExactlyOneOf(rules []Rule, option ...ValidatorOption) Validator {
+	if validator.stop {
+		return validator
+	}
+
+	probed := make([]Validator, len(rules))
+	codes := make([]any, len(rules))
+	for i, rule := range rules {
+		probed[i] = validator.probe(rule)
+		codes[i] = rule.Code()
+	}
+	return validator.combine("exactlyoneof", probed, func(failed [][]Violation) (map[string]any, bool) {
+		passed := 0
+		for _, violations := range failed {
+			if len(violations) == 0 {
+				passed++
+			}
+		}
+		return map[string]any{"rules": codes, "passed": passed}, passed != 1
+	}, option)
+}
@@ -0,0 +1,141 @@
+package gomal_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/ItsMalma/gomal"
+)
+
+func TestCombinators(t *testing.T) {
+	emailOrPhone := []gomal.Rule{gomal.Rules.Email(), gomal.Rules.E164Phone()}
+	tests := []struct {
+		name      string
+		validator gomal.Validator
+		results   []gomal.ValidationResult
+	}{
+		{
+			name:      "any of passes",
+			validator: gomal.If("contact", "+14155552671").AnyOf(emailOrPhone),
+			results:   []gomal.ValidationResult{},
+		},
+		{
+			name:      "any of fails",
+			validator: gomal.If("contact", "malma").AnyOf(emailOrPhone),
+			results: []gomal.ValidationResult{{Name: "contact", Messages: []string{
+				"contact must satisfy at least one of the following: contact is not a valid email address; contact must be a phone number in the E.164 format, such as +14155552671.",
+			}}},
+		},
+		{
+			name:      "all of",
+			validator: gomal.If("username", "A").AllOf([]gomal.Rule{gomal.Rules.MinLength(3), gomal.Rules.Slug()}),
+			results: []gomal.ValidationResult{{Name: "username", Messages: []string{
+				"username must satisfy all of the following: The length of username must be at least 3 characters. You entered 1 characters; username must contain only lowercase letters, digits and hyphens.",
+			}}},
+		},
+		{
+			name:      "not",
+			validator: gomal.If("username", "admin").Not(gomal.Rules.OneOf("admin", "root")),
+			results:   []gomal.ValidationResult{{Name: "username", Messages: []string{"username must not satisfy the oneof rule."}}},
+		},
+		{
+			name:      "exactly one of",
+			validator: gomal.If("host", "10.0.0.1").ExactlyOneOf([]gomal.Rule{gomal.Rules.IPv4(), gomal.Rules.IP()}),
+			results:   []gomal.ValidationResult{{Name: "host", Messages: []string{"host must satisfy exactly one of the ipv4, ip rules, but satisfies 2."}}},
+		},
+		{
+			name:      "all of applies the rules in order",
+			validator: gomal.If("username", "  a  ").AllOf([]gomal.Rule{gomal.Rules.Trim(), gomal.Rules.MinLength(3)}),
+			results: []gomal.ValidationResult{{Name: "username", Messages: []string{
+				"username must satisfy all of the following: The length of username must be at least 3 characters. You entered 1 characters.",
+			}}},
+		},
+		{
+			name:      "custom message",
+			validator: gomal.If("contact", "malma").AnyOf(emailOrPhone, gomal.ValidatorOption{ErrorMessage: "Enter an email address or a phone number"}),
+			results:   []gomal.ValidationResult{{Name: "contact", Messages: []string{"Enter an email address or a phone number"}}},
+		},
+		{
+			name: "custom message of a nested rule",
+			validator: gomal.If("age", 10).AnyOf([]gomal.Rule{
+				gomal.Rules.GreaterThanOrEqual(17, gomal.ValidatorOption{ErrorMessage: "You must be an adult"}),
+				gomal.Rules.Equal(0),
+			}),
+			results: []gomal.ValidationResult{{Name: "age", Messages: []string{
+				"age must satisfy at least one of the following: You must be an adult; age should be equal to 0.",
+			}}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			results := gomal.Validate(test.validator)
+			if !reflect.DeepEqual(results, test.results) {
+				tt.Fatalf("expected %#v but got %#v instead", test.results, results)
+			}
+		})
+	}
+}
+
+func TestCombinatorsContext(t *testing.T) {
+	taken := gomal.Rules.IsCtx(func(ctx context.Context, value any) error {
+		return errors.New("is already taken")
+	})
+	tests := []struct {
+		name      string
+		validator gomal.Validator
+		results   []gomal.ValidationResult
+	}{
+		{
+			name:      "any of",
+			validator: gomal.If("contact", "malma").AnyOf([]gomal.Rule{taken, gomal.Rules.Email()}),
+			results: []gomal.ValidationResult{{Name: "contact", Messages: []string{
+				"contact must satisfy at least one of the following: is already taken; contact is not a valid email address.",
+			}}},
+		},
+		{
+			name:      "not",
+			validator: gomal.If("contact", "malma").Not(taken),
+			results:   []gomal.ValidationResult{},
+		},
+		{
+			name:      "exactly one of",
+			validator: gomal.If("contact", "malma@example.com").ExactlyOneOf([]gomal.Rule{taken, gomal.Rules.Email()}),
+			results:   []gomal.ValidationResult{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			results, err := gomal.ValidateContext(context.Background(), test.validator)
+			if err != nil {
+				tt.Fatalf("expected no error but got %v instead", err)
+			}
+			if !reflect.DeepEqual(results, test.results) {
+				tt.Fatalf("expected %#v but got %#v instead", test.results, results)
+			}
+		})
+	}
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := gomal.ValidateContext(cancelled, gomal.If("contact", "malma").AnyOf([]gomal.Rule{taken})); err != context.Canceled {
+		t.Fatalf("expected %v but got %v instead", context.Canceled, err)
+	}
+}
+
+func TestCombinatorJSONSchema(t *testing.T) {
+	schema := gomal.Schema[user]().
+		Field("email", func(u user) any { return u.Email }, gomal.Rules.AnyOf([]gomal.Rule{gomal.Rules.Email(), gomal.Rules.E164Phone()}))
+
+	expected := []any{
+		map[string]any{"format": "email"},
+		map[string]any{"pattern": `^\+[1-9][0-9]{1,14}$`},
+	}
+	property := schema.JSONSchema()["properties"].(map[string]any)["email"].(map[string]any)
+	if !reflect.DeepEqual(property["anyOf"], expected) {
+		t.Fatalf("expected %#v but got %#v instead", expected, property["anyOf"])
+	}
+}
@@ -0,0 +1,271 @@
+package gomal
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// sibling is another field of the struct or schema being validated.
+type sibling struct {
+	path  string
+	value reflect.Value
+}
+
+// structSiblings looks up the exported fields of structValue by their Go or
+// json name, reporting them under prefix.
+func structSiblings(prefix string, structValue reflect.Value) func(name string) (sibling, bool) {
+	return func(name string) (sibling, bool) {
+		field, ok := siblingField(structValue.Type(), name)
+		if !ok {
+			return sibling{}, false
+		}
+		// A field promoted through a nil embedded pointer is reported as nil.
+		value, _ := structValue.FieldByIndexErr(field.Index)
+		return sibling{path: fieldPath(prefix, fieldName(field)), value: value}, true
+	}
+}
+
+// siblingField looks up the exported field of structType named name, by its Go
+// or json name.
+func siblingField(structType reflect.Type, name string) (reflect.StructField, bool) {
+	field, ok := structType.FieldByName(name)
+	if ok && field.IsExported() {
+		return field, true
+	}
+	for _, visible := range reflect.VisibleFields(structType) {
+		if visible.IsExported() && !visible.Anonymous && fieldName(visible) == name {
+			return visible, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// crossFieldRules lists the rules naming sibling fields, checked by
+// checkSiblings.
+var crossFieldRules = map[string]bool{
+	"equalfield":       true,
+	"notequalfield":    true,
+	"greaterthanfield": true,
+	"lessthanfield":    true,
+	"requiredif":       true,
+	"requiredwith":     true,
+}
+
+// checkSiblings reports the cross-field rules of a field of structType whose
+// siblings do not exist or, for the ordering rules, cannot be compared with
+// fieldType, so CheckTags finds them instead of ValidateStruct panicking.
+func checkSiblings(structType, fieldType reflect.Type, rules []Rule) error {
+	for _, rule := range rules {
+		if rule.Code() == "dive" {
+			itemRules, _ := rule.Params()["rules"].([]Rule)
+			for _, itemRule := range itemRules {
+				if crossFieldRules[itemRule.Code()] {
+					return fmt.Errorf("rule %q cannot follow dive, elements have no sibling fields", itemRule.Code())
+				}
+			}
+			continue
+		}
+		if !crossFieldRules[rule.Code()] {
+			continue
+		}
+
+		names := []any{rule.Params()["otherField"]}
+		if otherFields, ok := rule.Params()["otherFields"].([]any); ok {
+			names = otherFields
+		}
+		for _, name := range names {
+			field, ok := siblingField(structType, name.(string))
+			if !ok {
+				return fmt.Errorf("rule %q: %v has no field %v", rule.Code(), structType, name)
+			}
+			ordered := rule.Code() == "greaterthanfield" || rule.Code() == "lessthanfield"
+			if ordered && !comparableTypes(fieldType, field.Type) {
+				return fmt.Errorf("rule %q: cannot compare %v with %v", rule.Code(), fieldType, field.Type)
+			}
+		}
+	}
+	return nil
+}
+
+// comparableTypes reports whether compareValues accepts values of both types.
+func comparableTypes(fieldType, another reflect.Type) bool {
+	for fieldType.Kind() == reflect.Pointer {
+		fieldType = fieldType.Elem()
+	}
+	for another.Kind() == reflect.Pointer {
+		another = another.Elem()
+	}
+	if fieldType.Kind() == reflect.Interface || another.Kind() == reflect.Interface {
+		// The values decide when they are validated.
+		return true
+	}
+	if fieldType == timeType || another == timeType {
+		return fieldType == another
+	}
+	numeric := func(valueType reflect.Type) bool {
+		return isNumber(valueType.Kind()) || valueType.Kind() == reflect.String ||
+			valueType == bigIntType || valueType == bigRatType || valueType == bigFloatType
+	}
+	return numeric(fieldType) && numeric(another)
+}
+
+// sibling returns the field named name next to the validated one. It panics
+// when the validator does not come from a struct or schema, or when there is
+// no such field.
+func (validator Validator) sibling(name string) sibling {
+	if validator.siblings == nil {
+		panic(fmt.Sprintf("gomal: %v is not a field of a struct or schema", validator.name))
+	}
+	sibling, ok := validator.siblings(name)
+	if !ok {
+		panic(fmt.Sprintf("gomal: %v has no sibling field %v", validator.name, name))
+	}
+	return sibling
+}
+
+// EqualField checks that the value equals the sibling field named name, e.g.
+// a password confirmation. Siblings are the other fields of the struct passed
+// to ValidateStruct or Validator.Field, looked up by Go or json name, or of the
+// ObjectSchema, looked up by name.
+func (validator Validator) EqualField(name string, option ...ValidatorOption) Validator {
+	if validator.stop {
+		return validator
+	}
+
+	other := validator.sibling(name)
+	if !sameValue(other.value, validator.value) {
+		validator.addViolation("equalfield", map[string]any{"otherField": other.path}, "", option)
+	}
+	return validator
+}
+
+// NotEqualField checks that the value differs from the sibling field named
+// name, see EqualField.
+func (validator Validator) NotEqualField(name string, option ...ValidatorOption) Validator {
+	if validator.stop {
+		return validator
+	}
+
+	other := validator.sibling(name)
+	if sameValue(other.value, validator.value) {
+		validator.addViolation("notequalfield", map[string]any{"otherField": other.path}, "", option)
+	}
+	return validator
+}
+
+// GreaterThanField checks that the value is greater than the sibling field
+// named name, e.g. an end date after a start date. Numbers, strings and
+// time.Time values are compared; the rule is skipped when either is nil.
+func (validator Validator) GreaterThanField(name string, option ...ValidatorOption) Validator {
+	if validator.stop {
+		return validator
+	}
+
+	other := validator.sibling(name)
+	if result, ok := compareValues(validator.reflectValue, other.value); ok && result <= 0 {
+		validator.addViolation("greaterthanfield", map[string]any{"otherField": other.path}, "", option)
+	}
+	return validator
+}
+
+// LessThanField checks that the value is less than the sibling field named
+// name, see GreaterThanField.
+func (validator Validator) LessThanField(name string, option ...ValidatorOption) Validator {
+	if validator.stop {
+		return validator
+	}
+
+	other := validator.sibling(name)
+	if result, ok := compareValues(validator.reflectValue, other.value); ok && result >= 0 {
+		validator.addViolation("lessthanfield", map[string]any{"otherField": other.path}, "", option)
+	}
+	return validator
+}
+
+// RequiredIf checks that the value is not empty, as defined by NotEmpty, when
+// the sibling field named name equals one of values.
+func (validator Validator) RequiredIf(name string, values []any, option ...ValidatorOption) Validator {
+	if validator.stop {
+		return validator
+	}
+
+	other := validator.sibling(name)
+	for _, value := range values {
+		if sameValue(other.value, value) {
+			if isNil(validator.reflectValue) || isEmpty(indirect(validator.reflectValue)) {
+				validator.addViolation("requiredif", map[string]any{"otherField": other.path, "values": values}, "", option)
+			}
+			break
+		}
+	}
+	return validator
+}
+
+// RequiredWith checks that the value is not empty, as defined by NotEmpty, when
+// any of the sibling fields named names is not empty.
+func (validator Validator) RequiredWith(names []string, option ...ValidatorOption) Validator {
+	if validator.stop {
+		return validator
+	}
+
+	present := false
+	paths := make([]any, len(names))
+	for i, name := range names {
+		other := validator.sibling(name)
+		paths[i] = other.path
+		if !isNil(other.value) && !isEmpty(indirect(other.value)) {
+			present = true
+		}
+	}
+	if present && (isNil(validator.reflectValue) || isEmpty(indirect(validator.reflectValue))) {
+		validator.addViolation("requiredwith", map[string]any{"otherFields": paths}, "", option)
+	}
+	return validator
+}
+
+// sameValue reports whether value equals another. another is converted to the
+// type of value when both are of the same kind, so a named string type matches
+// a string constant, and strings match values formatted with %v, as written in
+// struct tags.
+func sameValue(value reflect.Value, another any) bool {
+	value = indirect(value)
+	anotherValue := indirect(reflect.ValueOf(another))
+	if !value.IsValid() || !anotherValue.IsValid() {
+		return !value.IsValid() && !anotherValue.IsValid()
+	}
+	if reflect.DeepEqual(value.Interface(), anotherValue.Interface()) {
+		return true
+	}
+	if value.Kind() == anotherValue.Kind() && anotherValue.Type().ConvertibleTo(value.Type()) && value.Type().Comparable() {
+		return value.Interface() == anotherValue.Convert(value.Type()).Interface()
+	}
+	if anotherValue.Kind() == reflect.String {
+		return fmt.Sprint(value.Interface()) == anotherValue.String()
+	}
+	return false
+}
+
+// compareValues compares two numbers, strings or time.Time values, returning
+// -1, 0 or +1. A decimal string is compared as a number with a number and as a
+// string with a string. It reports false when either is nil. Values that cannot
+// be ordered, such as a string that is not a decimal and a number, compare as
+// equal, so both GreaterThanField and LessThanField fail.
+func compareValues(value, another reflect.Value) (int, bool) {
+	value, another = indirect(value), indirect(another)
+	if !value.IsValid() || !another.IsValid() {
+		return 0, false
+	}
+
+	if value.Type() == timeType && another.Type() == timeType {
+		return value.Interface().(time.Time).Compare(another.Interface().(time.Time)), true
+	}
+	if isNumeric(value) && isNumeric(another) && (value.Kind() != reflect.String || another.Kind() != reflect.String) {
+		return compareNumber(value, another.Interface())
+	}
+	if value.Kind() == reflect.String && another.Kind() == reflect.String {
+		return strings.Compare(value.String(), another.String()), true
+	}
+	return 0, true
+}
@@ -0,0 +1,196 @@
+package gomal_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ItsMalma/gomal"
+)
+
+type country string
+
+type bookingRequest struct {
+	Password        string    `json:"password"`
+	ConfirmPassword string    `json:"confirm_password" gomal:"equalfield=Password"`
+	Start           time.Time `json:"start"`
+	End             time.Time `json:"end" gomal:"greaterthanfield=start"`
+	Country         country   `json:"country"`
+	VATID           *string   `json:"vat_id" gomal:"requiredif=Country|DE|FR"`
+	Email           string    `json:"email"`
+	Phone           string    `json:"phone"`
+	Contact         string    `json:"contact" gomal:"requiredwith=Email|Phone"`
+}
+
+func TestCrossField(t *testing.T) {
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	vatID := "DE123"
+	tests := []struct {
+		name    string
+		value   bookingRequest
+		results []gomal.ValidationResult
+	}{
+		{
+			name: "success",
+			value: bookingRequest{
+				Password: "secret", ConfirmPassword: "secret",
+				Start: start, End: start.Add(time.Hour),
+				Country: "DE", VATID: &vatID,
+				Email: "malma@example.com", Contact: "Malma",
+			},
+			results: []gomal.ValidationResult{},
+		},
+		{
+			name:    "success because conditions are not met",
+			value:   bookingRequest{Start: start, End: start.Add(time.Hour), Country: "ID"},
+			results: []gomal.ValidationResult{},
+		},
+		{
+			name: "failed",
+			value: bookingRequest{
+				Password: "secret", ConfirmPassword: "secreet",
+				Start: start, End: start,
+				Country: "FR",
+				Phone:   "+62811",
+			},
+			results: []gomal.ValidationResult{
+				{Name: "confirm_password", Messages: []string{"confirm_password must be equal to password."}},
+				{Name: "end", Messages: []string{"end must be greater than start."}},
+				{Name: "vat_id", Messages: []string{"vat_id is required when country is DE, FR."}},
+				{Name: "contact", Messages: []string{"contact is required when email, phone is present."}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			results := gomal.ValidateStruct(test.value)
+			if !reflect.DeepEqual(results, test.results) {
+				tt.Fatalf("expected %#v but got %#v instead", test.results, results)
+			}
+		})
+	}
+}
+
+func TestCrossFieldSchema(t *testing.T) {
+	schema := gomal.Schema[bookingRequest]().
+		Field("from", func(b bookingRequest) any { return b.Start }).
+		Field("until", func(b bookingRequest) any { return b.End }, gomal.Rules.GreaterThanField("from"))
+
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	catalog := gomal.Indonesian()
+	catalog.Fields["from"] = "Tanggal mulai"
+	catalog.Fields["until"] = "Tanggal selesai"
+
+	expected := []gomal.ValidationResult{
+		{Name: "until", Messages: []string{"Tanggal selesai harus lebih dari Tanggal mulai."}},
+	}
+	results := gomal.ValidateWith(catalog, schema.Validators(bookingRequest{Start: start, End: start.Add(-time.Hour)})...)
+	if !reflect.DeepEqual(results, expected) {
+		t.Fatalf("expected %#v but got %#v instead", expected, results)
+	}
+}
+
+func TestCrossFieldNested(t *testing.T) {
+	value := struct {
+		Booking bookingRequest `json:"booking"`
+	}{Booking: bookingRequest{Password: "a", ConfirmPassword: "b", End: time.Now()}}
+
+	expected := []gomal.Violation{
+		{Field: "booking.confirm_password", Rule: "equalfield", Params: map[string]any{"otherField": "booking.password"}, Value: "b", Message: "booking.confirm_password must be equal to booking.password."},
+	}
+	violations := gomal.StructViolations(value)
+	if !reflect.DeepEqual(violations, expected) {
+		t.Fatalf("expected %#v but got %#v instead", expected, violations)
+	}
+}
+
+func TestCrossFieldCheckTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   any
+		invalid bool
+	}{
+		{
+			name:  "valid",
+			value: bookingRequest{},
+		},
+		{
+			name: "misspelled sibling",
+			value: struct {
+				Password string `json:"password"`
+				Confirm  string `json:"confirm" gomal:"equalfield=Pasword"`
+			}{},
+			invalid: true,
+		},
+		{
+			name: "misspelled sibling of requiredwith",
+			value: struct {
+				Email string `json:"email"`
+				Phone string `json:"phone" gomal:"requiredwith=email|fax"`
+			}{},
+			invalid: true,
+		},
+		{
+			name: "incomparable sibling",
+			value: struct {
+				Start time.Time `json:"start"`
+				Count int       `json:"count" gomal:"greaterthanfield=start"`
+			}{},
+			invalid: true,
+		},
+		{
+			name: "sibling after dive",
+			value: struct {
+				Total int   `json:"total"`
+				Items []int `json:"items" gomal:"dive,lessthanfield=total"`
+			}{},
+			invalid: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			if err := gomal.CheckTags(test.value); (err != nil) != test.invalid {
+				tt.Fatalf("expected invalid to be %v but got %v instead", test.invalid, err)
+			}
+		})
+	}
+}
+
+func TestCrossFieldNotDecimal(t *testing.T) {
+	type limits struct {
+		Min int    `json:"min"`
+		Max string `json:"max" gomal:"greaterthanfield=Min"`
+	}
+
+	if err := gomal.CheckTags(limits{}); err != nil {
+		t.Fatalf("expected no error but got %v instead", err)
+	}
+
+	tests := []struct {
+		name    string
+		value   limits
+		results []gomal.ValidationResult
+	}{
+		{
+			name:    "decimal",
+			value:   limits{Min: 1, Max: "2.5"},
+			results: []gomal.ValidationResult{},
+		},
+		{
+			name:    "not a decimal",
+			value:   limits{Min: 1, Max: "abc"},
+			results: []gomal.ValidationResult{{Name: "max", Messages: []string{"max must be greater than min."}}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			results := gomal.ValidateStruct(test.value)
+			if !reflect.DeepEqual(results, test.results) {
+				tt.Fatalf("expected %#v but got %#v instead", test.results, results)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package gomal
+
+import "reflect"
+
+// OneOf checks that the value equals one of values. Values of a named type
+// match constants of its underlying type, so a Status matches "draft".
+//
+// Without values, OneOf uses the enum methods of the value's type: a Values
+// method returning the allowed values as a slice, or a Valid() bool method.
+// Allowed values are listed in the message with %v, so types implementing
+// fmt.Stringer are shown by name.
+func (validator Validator) OneOf(values ...any) Validator {
+	return validator.oneOf(values, nil)
+}
+
+// OneOfWith is OneOf applying option, e.g. for a custom message.
+func (validator Validator) OneOfWith(option ValidatorOption, values ...any) Validator {
+	return validator.oneOf(values, []ValidatorOption{option})
+}
+
+func (validator Validator) oneOf(values []any, option []ValidatorOption) Validator {
+	if validator.stop {
+		return validator
+	}
+
+	if len(values) == 0 {
+		var ok bool
+		if values, ok = enumValues(validator.reflectValue); !ok {
+			if valid, ok := validator.value.(interface{ Valid() bool }); ok && !valid.Valid() {
+				validator.addViolation("valid", nil, "", option)
+			}
+			return validator
+		}
+	}
+
+	if !containsSameValue(values, validator.reflectValue) {
+		validator.addViolation("oneof", map[string]any{"values": values}, "", option)
+	}
+	return validator
+}
+
+// NotOneOf checks that the value equals none of values, see OneOf.
+func (validator Validator) NotOneOf(values ...any) Validator {
+	return validator.notOneOf(values, nil)
+}
+
+// NotOneOfWith is NotOneOf applying option, e.g. for a custom message.
+func (validator Validator) NotOneOfWith(option ValidatorOption, values ...any) Validator {
+	return validator.notOneOf(values, []ValidatorOption{option})
+}
+
+func (validator Validator) notOneOf(values []any, option []ValidatorOption) Validator {
+	if validator.stop {
+		return validator
+	}
+
+	if containsSameValue(values, validator.reflectValue) {
+		validator.addViolation("notoneof", map[string]any{"values": values}, "", option)
+	}
+	return validator
+}
+
+func containsSameValue(values []any, value reflect.Value) bool {
+	for _, another := range values {
+		if sameValue(value, another) {
+			return true
+		}
+	}
+	return false
+}
+
+// enumValues calls the Values method of the type of value, which must take no
+// arguments and return a slice or array.
+func enumValues(value reflect.Value) ([]any, bool) {
+	if !value.IsValid() {
+		return nil, false
+	}
+	method := value.MethodByName("Values")
+	if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 1 {
+		return nil, false
+	}
+	switch method.Type().Out(0).Kind() {
+	case reflect.Array, reflect.Slice:
+	default:
+		return nil, false
+	}
+
+	list := method.Call(nil)[0]
+	values := make([]any, list.Len())
+	for i := range values {
+		values[i] = list.Index(i).Interface()
+	}
+	return values, true
+}
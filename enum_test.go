@@ -0,0 +1,121 @@
+package gomal_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ItsMalma/gomal"
+)
+
+type status string
+
+func (status) Values() []status {
+	return []status{"draft", "published", "archived"}
+}
+
+type priority int
+
+const (
+	low priority = iota + 1
+	high
+)
+
+func (p priority) String() string {
+	switch p {
+	case low:
+		return "low"
+	case high:
+		return "high"
+	}
+	return "unknown"
+}
+
+func (p priority) Valid() bool {
+	return p == low || p == high
+}
+
+func TestOneOf(t *testing.T) {
+	tests := []struct {
+		name      string
+		validator gomal.Validator
+		results   []gomal.ValidationResult
+	}{
+		{
+			name:      "plain values",
+			validator: gomal.If("status", "draft").OneOf("draft", "published"),
+			results:   []gomal.ValidationResult{},
+		},
+		{
+			name:      "named type against constants",
+			validator: gomal.If("status", status("deleted")).OneOf("draft", "published"),
+			results:   []gomal.ValidationResult{{Name: "status", Messages: []string{"status must be one of draft, published."}}},
+		},
+		{
+			name:      "values method",
+			validator: gomal.If("status", status("deleted")).OneOf(),
+			results:   []gomal.ValidationResult{{Name: "status", Messages: []string{"status must be one of draft, published, archived."}}},
+		},
+		{
+			name:      "valid method",
+			validator: gomal.If("priority", priority(7)).OneOf(),
+			results:   []gomal.ValidationResult{{Name: "priority", Messages: []string{"priority is not a valid value."}}},
+		},
+		{
+			name:      "stringer",
+			validator: gomal.If("priority", priority(7)).OneOf(low, high),
+			results:   []gomal.ValidationResult{{Name: "priority", Messages: []string{"priority must be one of low, high."}}},
+		},
+		{
+			name:      "not one of",
+			validator: gomal.If("username", "admin").NotOneOf("admin", "root"),
+			results:   []gomal.ValidationResult{{Name: "username", Messages: []string{"username must not be one of admin, root."}}},
+		},
+		{
+			name:      "custom message",
+			validator: gomal.If("status", "deleted").OneOfWith(gomal.ValidatorOption{ErrorMessage: "Pick a status"}, "draft", "published"),
+			results:   []gomal.ValidationResult{{Name: "status", Messages: []string{"Pick a status"}}},
+		},
+		{
+			name:      "custom message of the enum methods",
+			validator: gomal.If("priority", priority(7)).OneOfWith(gomal.ValidatorOption{ErrorMessage: "Pick a priority"}),
+			results:   []gomal.ValidationResult{{Name: "priority", Messages: []string{"Pick a priority"}}},
+		},
+		{
+			name:      "custom message of not one of",
+			validator: gomal.If("username", "root").NotOneOfWith(gomal.ValidatorOption{ErrorMessage: "Pick another username"}, "admin", "root"),
+			results:   []gomal.ValidationResult{{Name: "username", Messages: []string{"Pick another username"}}},
+		},
+		{
+			name:      "custom message of the rule",
+			validator: gomal.Rules.OneOfWith(gomal.ValidatorOption{ErrorMessage: "Pick a status"}, "draft").Apply(gomal.If("status", "deleted")),
+			results:   []gomal.ValidationResult{{Name: "status", Messages: []string{"Pick a status"}}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			results := gomal.Validate(test.validator)
+			if !reflect.DeepEqual(results, test.results) {
+				tt.Fatalf("expected %#v but got %#v instead", test.results, results)
+			}
+		})
+	}
+}
+
+func TestOneOfTag(t *testing.T) {
+	type post struct {
+		Status   status   `json:"status" gomal:"oneof"`
+		Priority priority `json:"priority" gomal:"oneof=1|2"`
+		Slug     string   `json:"slug" gomal:"notoneof=new|edit"`
+	}
+
+	expected := []gomal.ValidationResult{
+		{Name: "status", Messages: []string{"status must be one of draft, published, archived."}},
+		{Name: "priority", Messages: []string{"priority must be one of low, high."}},
+		{Name: "slug", Messages: []string{"slug must not be one of new, edit."}},
+	}
+	results := gomal.ValidateStruct(post{Status: "deleted", Priority: 3, Slug: "new"})
+	if !reflect.DeepEqual(results, expected) {
+		t.Fatalf("expected %#v but got %#v instead", expected, results)
+	}
+}
@@ -0,0 +1,81 @@
+package gomal
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// ErrValidation is matched by errors.Is for every ValidationErrors.
+var ErrValidation = errors.New("gomal: validation failed")
+
+// ValidationErrors is the error returned by Check and CheckStruct. Any result
+// list can be turned into one, e.g. gomal.ValidationErrors(gomal.ValidateWith(...)).
+//
+// Each ValidationResult is an error of its own and is returned by Unwrap, so a
+// single field's failure can be extracted with errors.As.
+type ValidationErrors []ValidationResult
+
+func (result ValidationResult) Error() string {
+	return result.Name + ": " + strings.Join(result.Messages, "; ")
+}
+
+func (errs ValidationErrors) Error() string {
+	messages := make([]string, len(errs))
+	for i, result := range errs {
+		messages[i] = result.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+func (errs ValidationErrors) Is(target error) bool {
+	return target == ErrValidation
+}
+
+func (errs ValidationErrors) Unwrap() []error {
+	unwrapped := make([]error, len(errs))
+	for i, result := range errs {
+		unwrapped[i] = result
+	}
+	return unwrapped
+}
+
+// Field returns the result of the field with the given name.
+func (errs ValidationErrors) Field(name string) (ValidationResult, bool) {
+	for _, result := range errs {
+		if result.Name == name {
+			return result, true
+		}
+	}
+	return ValidationResult{}, false
+}
+
+// MarshalJSON encodes the errors as {"errors": {"field": ["message", ...]}}.
+func (errs ValidationErrors) MarshalJSON() ([]byte, error) {
+	fields := make(map[string][]string, len(errs))
+	for _, result := range errs {
+		fields[result.Name] = append(fields[result.Name], result.Messages...)
+	}
+	return json.Marshal(struct {
+		Errors map[string][]string `json:"errors"`
+	}{Errors: fields})
+}
+
+// Check validates like Validate and returns the failures as ValidationErrors,
+// or nil when every validator passed.
+func Check(validators ...Validator) error {
+	return toError(Validate(validators...))
+}
+
+// CheckStruct validates like ValidateStruct and returns the failures as
+// ValidationErrors, or nil when every field passed.
+func CheckStruct(v any) error {
+	return toError(ValidateStruct(v))
+}
+
+func toError(results []ValidationResult) error {
+	if len(results) < 1 {
+		return nil
+	}
+	return ValidationErrors(results)
+}
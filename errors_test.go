@@ -0,0 +1,72 @@
+package gomal_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/ItsMalma/gomal"
+)
+
+func TestCheck(t *testing.T) {
+	if err := gomal.Check(gomal.If("x", "hello").NotEmpty()); err != nil {
+		t.Fatalf("expected nil but got %v instead", err)
+	}
+
+	err := gomal.Check(
+		gomal.If("email", "").NotEmpty().Email(),
+		gomal.If("age", 10).GreaterThan(17),
+	)
+	if err == nil {
+		t.Fatalf("expected error but got nil instead")
+	}
+	if !errors.Is(err, gomal.ErrValidation) {
+		t.Fatalf("expected %v to match ErrValidation", err)
+	}
+
+	expectedMessage := "email: email should not be empty.; email is not a valid email address\nage: age must be greater than 17."
+	if err.Error() != expectedMessage {
+		t.Fatalf("expected %q but got %q instead", expectedMessage, err.Error())
+	}
+
+	var result gomal.ValidationResult
+	if !errors.As(err, &result) || result.Name != "email" {
+		t.Fatalf("expected the email result but got %#v instead", result)
+	}
+
+	var errs gomal.ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected ValidationErrors but got %T instead", err)
+	}
+	if age, ok := errs.Field("age"); !ok || !reflect.DeepEqual(age.Messages, []string{"age must be greater than 17."}) {
+		t.Fatalf("expected the age result but got %#v instead", age)
+	}
+}
+
+func TestValidationErrorsMarshalJSON(t *testing.T) {
+	errs := gomal.ValidationErrors{
+		{Name: "name", Messages: []string{"name should not be empty."}},
+		{Name: "email", Messages: []string{"email should not be empty.", "email is not a valid email address"}},
+		{Name: "name", Messages: []string{"name must be empty"}},
+	}
+
+	data, err := json.Marshal(errs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"errors":{"email":["email should not be empty.","email is not a valid email address"],"name":["name should not be empty.","name must be empty"]}}`
+	if string(data) != expected {
+		t.Fatalf("expected %s but got %s instead", expected, data)
+	}
+}
+
+func TestCheckStruct(t *testing.T) {
+	err := gomal.CheckStruct(struct {
+		Name string `json:"name" gomal:"notempty"`
+	}{})
+	if !errors.Is(err, gomal.ErrValidation) {
+		t.Fatalf("expected validation error but got %v instead", err)
+	}
+}
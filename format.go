@@ -0,0 +1,247 @@
+package gomal
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net"
+	"net/mail"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	uuidRegexp   = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	labelRegexp  = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?$`)
+	e164Regexp   = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+	hexRegexp    = regexp.MustCompile(`^[0-9a-fA-F]+$`)
+	slugRegexp   = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	semverRegexp = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+		`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+		`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+)
+
+// checkFormat records a violation of rule when the value is a string that
+// fails check. Values of other kinds are skipped.
+func (validator Validator) checkFormat(rule string, params map[string]any, check func(value string) bool, option []ValidatorOption) Validator {
+	if validator.stop {
+		return validator
+	}
+
+	if validator.valueType != nil && validator.valueType.Kind() == reflect.String && !check(validator.reflectValue.String()) {
+		validator.addViolation(rule, params, "", option)
+	}
+	return validator
+}
+
+// StrictEmail is Email rejecting anything but a bare address, such as
+// "Malma <malma@example.com>".
+func (validator Validator) StrictEmail(option ...ValidatorOption) Validator {
+	return validator.checkFormat("strictemail", nil, isStrictEmail, option)
+}
+
+// URL checks for an absolute URL. When schemes are given, such as
+// []string{"https"}, the URL must use one of them.
+func (validator Validator) URL(schemes []string, option ...ValidatorOption) Validator {
+	var params map[string]any
+	if len(schemes) > 0 {
+		params = map[string]any{"schemes": stringsToAny(schemes)}
+	}
+	return validator.checkFormat("url", params, func(value string) bool { return isURL(value, schemes) }, option)
+}
+
+// UUID checks for a UUID in its canonical 8-4-4-4-12 form. A version from 1 to
+// 8 also checks the version and the RFC 4122 variant; 0 accepts any.
+func (validator Validator) UUID(version int, option ...ValidatorOption) Validator {
+	var params map[string]any
+	if version > 0 {
+		params = map[string]any{"version": version}
+	}
+	return validator.checkFormat("uuid", params, func(value string) bool { return isUUID(value, version) }, option)
+}
+
+// IP checks for an IPv4 or IPv6 address.
+func (validator Validator) IP(option ...ValidatorOption) Validator {
+	return validator.checkFormat("ip", nil, isIP, option)
+}
+
+func (validator Validator) IPv4(option ...ValidatorOption) Validator {
+	return validator.checkFormat("ipv4", nil, isIPv4, option)
+}
+
+func (validator Validator) IPv6(option ...ValidatorOption) Validator {
+	return validator.checkFormat("ipv6", nil, isIPv6, option)
+}
+
+// CIDR checks for an IP network such as "10.0.0.0/8" or "2001:db8::/32".
+func (validator Validator) CIDR(option ...ValidatorOption) Validator {
+	return validator.checkFormat("cidr", nil, isCIDR, option)
+}
+
+// Hostname checks for an RFC 1123 host name, such as "localhost" or
+// "api.example.com".
+func (validator Validator) Hostname(option ...ValidatorOption) Validator {
+	return validator.checkFormat("hostname", nil, isHostname, option)
+}
+
+// FQDN checks for a fully qualified domain name: a host name with at least two
+// labels and a non-numeric top-level domain, optionally ending with a dot.
+func (validator Validator) FQDN(option ...ValidatorOption) Validator {
+	return validator.checkFormat("fqdn", nil, isFQDN, option)
+}
+
+// MACAddress checks for an IEEE 802 MAC-48, EUI-48, EUI-64 or 20-octet
+// InfiniBand address, as accepted by net.ParseMAC.
+func (validator Validator) MACAddress(option ...ValidatorOption) Validator {
+	return validator.checkFormat("macaddress", nil, isMACAddress, option)
+}
+
+// E164Phone checks for a phone number in the E.164 format, such as
+// "+14155552671".
+func (validator Validator) E164Phone(option ...ValidatorOption) Validator {
+	return validator.checkFormat("e164phone", nil, e164Regexp.MatchString, option)
+}
+
+// ISO8601Date checks for a calendar date such as "2024-05-01".
+func (validator Validator) ISO8601Date(option ...ValidatorOption) Validator {
+	return validator.checkFormat("iso8601date", nil, isISO8601Date, option)
+}
+
+// RFC3339 checks for a date and time such as "2024-05-01T08:30:00+07:00".
+func (validator Validator) RFC3339(option ...ValidatorOption) Validator {
+	return validator.checkFormat("rfc3339", nil, isRFC3339, option)
+}
+
+// Base64 checks for standard, padded Base64.
+func (validator Validator) Base64(option ...ValidatorOption) Validator {
+	return validator.checkFormat("base64", nil, isBase64, option)
+}
+
+// Hex checks for a non-empty string of hexadecimal digits.
+func (validator Validator) Hex(option ...ValidatorOption) Validator {
+	return validator.checkFormat("hex", nil, hexRegexp.MatchString, option)
+}
+
+// JSON checks for a valid JSON document.
+func (validator Validator) JSON(option ...ValidatorOption) Validator {
+	return validator.checkFormat("json", nil, isJSON, option)
+}
+
+// Semver checks for a semantic version such as "1.2.3" or "2.0.0-rc.1+build.5",
+// without a "v" prefix.
+func (validator Validator) Semver(option ...ValidatorOption) Validator {
+	return validator.checkFormat("semver", nil, semverRegexp.MatchString, option)
+}
+
+// Slug checks for lowercase letters and digits separated by single hyphens,
+// such as "hello-world-2".
+func (validator Validator) Slug(option ...ValidatorOption) Validator {
+	return validator.checkFormat("slug", nil, slugRegexp.MatchString, option)
+}
+
+func isStrictEmail(value string) bool {
+	address, err := mail.ParseAddress(value)
+	return err == nil && address.Name == "" && address.Address == value
+}
+
+func isURL(value string, schemes []string) bool {
+	parsed, err := url.Parse(value)
+	if err != nil || parsed.Scheme == "" || (parsed.Host == "" && parsed.Opaque == "") {
+		return false
+	}
+	if len(schemes) == 0 {
+		return true
+	}
+	for _, scheme := range schemes {
+		if strings.EqualFold(parsed.Scheme, scheme) {
+			return true
+		}
+	}
+	return false
+}
+
+func isUUID(value string, version int) bool {
+	if !uuidRegexp.MatchString(value) {
+		return false
+	}
+	if version == 0 {
+		return true
+	}
+	return value[14] == byte('0'+version) && strings.ContainsRune("89abAB", rune(value[19]))
+}
+
+func isIP(value string) bool {
+	_, err := netip.ParseAddr(value)
+	return err == nil
+}
+
+func isIPv4(value string) bool {
+	addr, err := netip.ParseAddr(value)
+	return err == nil && addr.Is4()
+}
+
+func isIPv6(value string) bool {
+	addr, err := netip.ParseAddr(value)
+	return err == nil && addr.Is6()
+}
+
+func isCIDR(value string) bool {
+	_, err := netip.ParsePrefix(value)
+	return err == nil
+}
+
+func isHostname(value string) bool {
+	if len(value) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(value, ".") {
+		if !labelRegexp.MatchString(label) {
+			return false
+		}
+	}
+	return true
+}
+
+func isFQDN(value string) bool {
+	value = strings.TrimSuffix(value, ".")
+	i := strings.LastIndex(value, ".")
+	if i < 0 || !isHostname(value) {
+		return false
+	}
+	return strings.ContainsFunc(value[i+1:], func(r rune) bool { return r < '0' || r > '9' })
+}
+
+func isMACAddress(value string) bool {
+	_, err := net.ParseMAC(value)
+	return err == nil
+}
+
+func isISO8601Date(value string) bool {
+	_, err := time.Parse(time.DateOnly, value)
+	return err == nil
+}
+
+func isRFC3339(value string) bool {
+	_, err := time.Parse(time.RFC3339, value)
+	return err == nil
+}
+
+func isBase64(value string) bool {
+	_, err := base64.StdEncoding.DecodeString(value)
+	return err == nil
+}
+
+func isJSON(value string) bool {
+	return json.Valid([]byte(value))
+}
+
+func stringsToAny(values []string) []any {
+	converted := make([]any, len(values))
+	for i, value := range values {
+		converted[i] = value
+	}
+	return converted
+}
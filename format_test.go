@@ -0,0 +1,195 @@
+package gomal_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ItsMalma/gomal"
+)
+
+func TestFormats(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    gomal.Rule
+		valid   []string
+		invalid []string
+		message string
+	}{
+		{
+			name:    "strict email",
+			rule:    gomal.Rules.StrictEmail(),
+			valid:   []string{"malma@example.com"},
+			invalid: []string{"Malma <malma@example.com>", "malma"},
+			message: "x is not a valid email address",
+		},
+		{
+			name:    "url",
+			rule:    gomal.Rules.URL([]string{"https"}),
+			valid:   []string{"https://example.com/a?b=c", "HTTPS://example.com"},
+			invalid: []string{"http://example.com", "example.com", "/relative"},
+			message: "x must be a valid URL.",
+		},
+		{
+			name:    "uuid",
+			rule:    gomal.Rules.UUID(4),
+			valid:   []string{"9b2f2a43-6d43-4f0e-9a43-52b4e3f6c1de"},
+			invalid: []string{"9b2f2a43-6d43-1f0e-9a43-52b4e3f6c1de", "9b2f2a436d434f0e9a4352b4e3f6c1de"},
+			message: "x must be a valid UUID.",
+		},
+		{
+			name:    "ip",
+			rule:    gomal.Rules.IP(),
+			valid:   []string{"192.168.1.1", "2001:db8::1"},
+			invalid: []string{"256.1.1.1", "localhost"},
+			message: "x must be a valid IP address.",
+		},
+		{
+			name:    "ipv4",
+			rule:    gomal.Rules.IPv4(),
+			valid:   []string{"10.0.0.1"},
+			invalid: []string{"2001:db8::1"},
+			message: "x must be a valid IPv4 address.",
+		},
+		{
+			name:    "ipv6",
+			rule:    gomal.Rules.IPv6(),
+			valid:   []string{"2001:db8::1"},
+			invalid: []string{"10.0.0.1"},
+			message: "x must be a valid IPv6 address.",
+		},
+		{
+			name:    "cidr",
+			rule:    gomal.Rules.CIDR(),
+			valid:   []string{"10.0.0.0/8", "2001:db8::/32"},
+			invalid: []string{"10.0.0.0", "10.0.0.0/33"},
+			message: "x must be a valid CIDR block.",
+		},
+		{
+			name:    "hostname",
+			rule:    gomal.Rules.Hostname(),
+			valid:   []string{"localhost", "api.example.com"},
+			invalid: []string{"-api.example.com", "api_1.example.com", ""},
+			message: "x must be a valid hostname.",
+		},
+		{
+			name:    "fqdn",
+			rule:    gomal.Rules.FQDN(),
+			valid:   []string{"example.com", "api.example.com."},
+			invalid: []string{"localhost", "10.0.0.1"},
+			message: "x must be a fully qualified domain name.",
+		},
+		{
+			name:    "mac address",
+			rule:    gomal.Rules.MACAddress(),
+			valid:   []string{"00:1a:2b:3c:4d:5e", "00-1A-2B-3C-4D-5E"},
+			invalid: []string{"00:1a:2b:3c:4d"},
+			message: "x must be a valid MAC address.",
+		},
+		{
+			name:    "e164 phone",
+			rule:    gomal.Rules.E164Phone(),
+			valid:   []string{"+14155552671", "+6281234567890"},
+			invalid: []string{"14155552671", "+0123", "+1 415 555 2671"},
+			message: "x must be a phone number in the E.164 format, such as +14155552671.",
+		},
+		{
+			name:    "iso8601 date",
+			rule:    gomal.Rules.ISO8601Date(),
+			valid:   []string{"2024-02-29"},
+			invalid: []string{"2023-02-29", "01/05/2024"},
+			message: "x must be a date in the YYYY-MM-DD format.",
+		},
+		{
+			name:    "rfc3339",
+			rule:    gomal.Rules.RFC3339(),
+			valid:   []string{"2024-05-01T08:30:00+07:00", "2024-05-01T01:30:00Z"},
+			invalid: []string{"2024-05-01", "2024-05-01 08:30:00"},
+			message: "x must be a date and time in the RFC 3339 format.",
+		},
+		{
+			name:    "base64",
+			rule:    gomal.Rules.Base64(),
+			valid:   []string{"aGVsbG8="},
+			invalid: []string{"aGVsbG8", "hello!"},
+			message: "x must be valid Base64.",
+		},
+		{
+			name:    "hex",
+			rule:    gomal.Rules.Hex(),
+			valid:   []string{"deadBEEF"},
+			invalid: []string{"0xdeadbeef", ""},
+			message: "x must be a hexadecimal string.",
+		},
+		{
+			name:    "json",
+			rule:    gomal.Rules.JSON(),
+			valid:   []string{`{"a":[1,2]}`, "null"},
+			invalid: []string{`{"a":}`, ""},
+			message: "x must be valid JSON.",
+		},
+		{
+			name:    "semver",
+			rule:    gomal.Rules.Semver(),
+			valid:   []string{"1.2.3", "2.0.0-rc.1+build.5"},
+			invalid: []string{"v1.2.3", "1.2", "01.2.3"},
+			message: "x must be a semantic version, such as 1.2.3.",
+		},
+		{
+			name:    "slug",
+			rule:    gomal.Rules.Slug(),
+			valid:   []string{"hello-world-2"},
+			invalid: []string{"Hello-World", "hello--world", "-hello"},
+			message: "x must contain only lowercase letters, digits and hyphens.",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			for _, value := range test.valid {
+				if results := gomal.Validate(test.rule.Apply(gomal.If("x", value))); !reflect.DeepEqual(results, []gomal.ValidationResult{}) {
+					tt.Fatalf("expected %q to be valid but got %#v instead", value, results)
+				}
+			}
+			for _, value := range test.invalid {
+				expected := []gomal.ValidationResult{{Name: "x", Messages: []string{test.message}}}
+				if results := gomal.Validate(test.rule.Apply(gomal.If("x", value))); !reflect.DeepEqual(results, expected) {
+					tt.Fatalf("expected %q to be invalid with %#v but got %#v instead", value, expected, results)
+				}
+			}
+		})
+	}
+}
+
+func TestFormatTags(t *testing.T) {
+	type webhook struct {
+		URL     string `json:"url" gomal:"url=https"`
+		ID      string `json:"id" gomal:"uuid=4"`
+		Contact string `json:"contact" gomal:"email=strict"`
+	}
+
+	expected := []gomal.ValidationResult{
+		{Name: "url", Messages: []string{"url must be a valid URL."}},
+		{Name: "id", Messages: []string{"id must be a valid UUID."}},
+		{Name: "contact", Messages: []string{"contact is not a valid email address"}},
+	}
+	results := gomal.ValidateStruct(webhook{URL: "ftp://example.com", ID: "x", Contact: "Malma <malma@example.com>"})
+	if !reflect.DeepEqual(results, expected) {
+		t.Fatalf("expected %#v but got %#v instead", expected, results)
+	}
+}
+
+func TestStrictEmailViolation(t *testing.T) {
+	violations := gomal.Violations(
+		gomal.If("email", "Malma <malma@example.com>").StrictEmail(),
+		gomal.If("contact", "malma").Email(),
+	)
+	if len(violations) != 2 {
+		t.Fatalf("expected 2 violations but got %#v instead", violations)
+	}
+	if violations[0].Rule != gomal.Rules.StrictEmail().Code() || violations[0].Rule != "strictemail" {
+		t.Fatalf("expected %#v but got %#v instead", "strictemail", violations[0].Rule)
+	}
+	if violations[1].Rule != "email" {
+		t.Fatalf("expected %#v but got %#v instead", "email", violations[1].Rule)
+	}
+}
@@ -1,3 +1,3 @@
 module github.com/ItsMalma/gomal
 
-go 1.19
+go 1.22
@@ -1,19 +1,168 @@
 package gomal
 
+import (
+	"context"
+	"sync"
+)
+
 type ValidationResult struct {
 	Name     string
 	Messages []string
 }
 
+// Violation describes a single failed rule in a form clients can render and
+// aggregate themselves. Rule is a stable code such as "length" and Params holds
+// its arguments, e.g. {"min": 3, "max": 64, "actual": 2}.
+type Violation struct {
+	Field   string
+	Rule    string
+	Params  map[string]any
+	Value   any
+	Message string
+}
+
 func Validate(validators ...Validator) []ValidationResult {
+	return ValidateWith(defaultTranslator, validators...)
+}
+
+// ValidateWith is Validate rendering the messages of built-in rules with
+// translator instead of the English catalog.
+func ValidateWith(translator Translator, validators ...Validator) []ValidationResult {
+	ctx := WithTranslator(context.Background(), translator)
+	results := []ValidationResult{}
+	for _, validator := range validators {
+		validator, _ = validator.resolve(ctx)
+		results = appendResults(results, translator, validator)
+	}
+	return results
+}
+
+// ValidateContext is Validate passing ctx to the rules added with IsCtx and
+// rendering messages with the translator carried by ctx, see TranslatorFrom.
+// It stops and returns the context's error as soon as ctx is done.
+func ValidateContext(ctx context.Context, validators ...Validator) ([]ValidationResult, error) {
+	translator := TranslatorFrom(ctx)
 	results := []ValidationResult{}
 	for _, validator := range validators {
-		if len(validator.errorMessages) > 0 {
-			results = append(results, ValidationResult{
-				Name:     validator.name,
-				Messages: validator.errorMessages,
-			})
+		validator, err := validator.resolve(ctx)
+		if err != nil {
+			return nil, err
 		}
+		results = appendResults(results, translator, validator)
+	}
+	return results, nil
+}
+
+// ValidateParallel is ValidateContext resolving up to workers validators at the
+// same time, which pays off when many validators carry slow IsCtx rules such
+// as remote lookups. The results keep the order of validators. The rules of a
+// single validator still run one after another, so callbacks shared between
+// validators must be safe for concurrent use.
+func ValidateParallel(ctx context.Context, workers int, validators ...Validator) ([]ValidationResult, error) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	resolved := make([]Validator, len(validators))
+	jobs := make(chan int)
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	for w := 0; w < min(workers, len(validators)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				validator, err := validators[i].resolve(ctx)
+				if err != nil {
+					errOnce.Do(func() { firstErr = err })
+					continue
+				}
+				resolved[i] = validator
+			}
+		}()
+	}
+
+feed:
+	for i := range validators {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	translator := TranslatorFrom(ctx)
+	results := []ValidationResult{}
+	for _, validator := range resolved {
+		results = appendResults(results, translator, validator)
+	}
+	return results, nil
+}
+
+// Violations returns every violation recorded by the validators, including
+// nested fields and elements, in the same order as Validate. Messages are
+// rendered with the English catalog.
+func Violations(validators ...Validator) []Violation {
+	return ViolationsWith(defaultTranslator, validators...)
+}
+
+// ViolationsWith is Violations rendering the messages of built-in rules with
+// translator, see ValidateWith.
+func ViolationsWith(translator Translator, validators ...Validator) []Violation {
+	ctx := WithTranslator(context.Background(), translator)
+	violations := []Violation{}
+	for _, validator := range validators {
+		validator, _ = validator.resolve(ctx)
+		violations = appendViolations(violations, translator, validator)
+	}
+	return violations
+}
+
+// appendResults appends the result of validator followed by the results of its
+// nested fields and elements.
+func appendResults(results []ValidationResult, translator Translator, validator Validator) []ValidationResult {
+	if len(validator.violations) > 0 {
+		messages := make([]string, len(validator.violations))
+		for i, violation := range validator.violations {
+			messages[i] = render(translator, violation).Message
+		}
+		results = append(results, ValidationResult{
+			Name:     validator.name,
+			Messages: messages,
+		})
+	}
+	for _, child := range validator.children {
+		results = appendResults(results, translator, child)
 	}
 	return results
 }
+
+func appendViolations(violations []Violation, translator Translator, validator Validator) []Violation {
+	for _, violation := range validator.violations {
+		violations = append(violations, render(translator, violation))
+	}
+	for _, child := range validator.children {
+		violations = appendViolations(violations, translator, child)
+	}
+	return violations
+}
+
+// render fills in the message of a violation recorded by a built-in rule.
+func render(translator Translator, violation Violation) Violation {
+	if violation.Message == "" {
+		violation.Message = translator.Translate(violation)
+	}
+	return violation
+}
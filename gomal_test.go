@@ -0,0 +1,180 @@
+package gomal_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ItsMalma/gomal"
+)
+
+func TestViolations(t *testing.T) {
+	violations := gomal.Violations(
+		gomal.If("username", "ab").NotEmpty().Length(3, 16),
+		gomal.If("age", 10).GreaterThan(17, gomal.ValidatorOption{ErrorMessage: "too young"}),
+		gomal.If("email", "malma@example.com").Email(),
+	)
+
+	expected := []gomal.Violation{
+		{
+			Field:   "username",
+			Rule:    "length",
+			Params:  map[string]any{"min": 3, "max": 16, "actual": 2},
+			Value:   "ab",
+			Message: "username must be between 3 and 16 characters. You entered 2 characters",
+		},
+		{
+			Field:   "age",
+			Rule:    "greaterthan",
+			Params:  map[string]any{"limit": 17},
+			Value:   10,
+			Message: "too young",
+		},
+	}
+	if !reflect.DeepEqual(violations, expected) {
+		t.Fatalf("expected %#v but got %#v instead", expected, violations)
+	}
+}
+
+func TestStructViolations(t *testing.T) {
+	violations := gomal.StructViolations(struct {
+		Items []struct {
+			SKU string `json:"sku" gomal:"notempty"`
+		} `json:"items"`
+	}{
+		Items: []struct {
+			SKU string `json:"sku" gomal:"notempty"`
+		}{{SKU: ""}},
+	})
+
+	expected := []gomal.Violation{{Field: "items[0].sku", Rule: "notempty", Value: "", Message: "items[0].sku should not be empty."}}
+	if !reflect.DeepEqual(violations, expected) {
+		t.Fatalf("expected %#v but got %#v instead", expected, violations)
+	}
+}
+
+func TestViolationsWith(t *testing.T) {
+	violations := gomal.ViolationsWith(gomal.Indonesian(), gomal.If("username", "").NotEmpty())
+	expected := []gomal.Violation{{Field: "username", Rule: "notempty", Value: "", Message: "username tidak boleh kosong."}}
+	if !reflect.DeepEqual(violations, expected) {
+		t.Fatalf("expected %#v but got %#v instead", expected, violations)
+	}
+
+	violations = gomal.StructViolationsWith(gomal.Indonesian(), struct {
+		SKU string `json:"sku" gomal:"notempty"`
+	}{})
+	expected = []gomal.Violation{{Field: "sku", Rule: "notempty", Value: "", Message: "sku tidak boleh kosong."}}
+	if !reflect.DeepEqual(violations, expected) {
+		t.Fatalf("expected %#v but got %#v instead", expected, violations)
+	}
+}
+
+func TestValidateContext(t *testing.T) {
+	taken := map[string]bool{"malma": true}
+	notTaken := func(ctx context.Context, value any) error {
+		if taken[value.(string)] {
+			return fmt.Errorf("username %v is already taken", value)
+		}
+		return nil
+	}
+
+	results, err := gomal.ValidateContext(context.Background(),
+		gomal.If("username", "malma").NotEmpty().IsCtx(notTaken),
+		gomal.If("other", "someone").IsCtx(notTaken),
+		gomal.If("skipped", "malma").When(false).IsCtx(notTaken),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []gomal.ValidationResult{{Name: "username", Messages: []string{"username malma is already taken"}}}
+	if !reflect.DeepEqual(results, expected) {
+		t.Fatalf("expected %#v but got %#v instead", expected, results)
+	}
+
+	if results := gomal.Validate(gomal.If("username", "malma").IsCtx(notTaken)); !reflect.DeepEqual(results, expected) {
+		t.Fatalf("expected %#v but got %#v instead", expected, results)
+	}
+}
+
+func TestValidateContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	slow := func(ctx context.Context, value any) error {
+		calls++
+		cancel()
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	results, err := gomal.ValidateContext(ctx,
+		gomal.If("a", 1).IsCtx(slow),
+		gomal.If("b", 2).IsCtx(slow),
+	)
+	if !errors.Is(err, context.Canceled) || results != nil {
+		t.Fatalf("expected context.Canceled but got %v, %v instead", results, err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected validation to stop after 1 call but got %v", calls)
+	}
+}
+
+func TestValidateParallel(t *testing.T) {
+	var running, maxRunning int32
+	slowEven := func(ctx context.Context, value any) error {
+		current := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			observed := atomic.LoadInt32(&maxRunning)
+			if current <= observed || atomic.CompareAndSwapInt32(&maxRunning, observed, current) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		if value.(int)%2 == 0 {
+			return fmt.Errorf("row %v is even", value)
+		}
+		return nil
+	}
+
+	validators := []gomal.Validator{}
+	expected := []gomal.ValidationResult{}
+	for i := 0; i < 20; i++ {
+		name := fmt.Sprintf("rows[%v]", i)
+		validators = append(validators, gomal.If(name, i).IsCtx(slowEven))
+		if i%2 == 0 {
+			expected = append(expected, gomal.ValidationResult{Name: name, Messages: []string{fmt.Sprintf("row %v is even", i)}})
+		}
+	}
+
+	results, err := gomal.ValidateParallel(context.Background(), 4, validators...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(results, expected) {
+		t.Fatalf("expected %#v but got %#v instead", expected, results)
+	}
+	if maxRunning > 4 {
+		t.Fatalf("expected at most 4 concurrent rules but got %v", maxRunning)
+	}
+}
+
+func TestValidateParallelCanceled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	blocking := func(ctx context.Context, value any) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	validators := []gomal.Validator{}
+	for i := 0; i < 10; i++ {
+		validators = append(validators, gomal.If("x", i).IsCtx(blocking))
+	}
+	if _, err := gomal.ValidateParallel(ctx, 2, validators...); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded but got %v instead", err)
+	}
+}
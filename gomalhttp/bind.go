@@ -0,0 +1,195 @@
+// Package gomalhttp binds net/http requests into structs, validates them with
+// gomal and reports failures as RFC 7807 problem details.
+package gomalhttp
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// maxMemory is the amount of a multipart form body kept in memory by Bind.
+const maxMemory = 32 << 20
+
+// MaxBodySize is the largest JSON body Bind decodes, in bytes, 1 MiB unless
+// changed, e.g. from an init function. A larger body fails with an
+// *http.MaxBytesError, which BindProblem answers with a 413 status.
+var MaxBodySize int64 = 1 << 20
+
+// BindError reports a request value that could not be decoded into its field.
+type BindError struct {
+	// Source is where the value comes from: "body", "form", "query", "header"
+	// or "path".
+	Source string
+	// Name is the parameter name, empty for a malformed body.
+	Name string
+	Err  error
+}
+
+func (err *BindError) Error() string {
+	if err.Name == "" {
+		return fmt.Sprintf("gomalhttp: invalid %v: %v", err.Source, err.Err)
+	}
+	return fmt.Sprintf("gomalhttp: invalid %v parameter %v: %v", err.Source, err.Name, err.Err)
+}
+
+func (err *BindError) Unwrap() error {
+	return err.Err
+}
+
+// bindSources lists the struct tags read by Bind, in the order they are
+// applied. A later source overwrites the value of an earlier one.
+var bindSources = []string{"form", "query", "header", "path"}
+
+// Bind decodes r into dst, which must be a pointer to a struct.
+//
+// A JSON body (application/json or any +json type) of up to MaxBodySize bytes
+// is decoded first with encoding/json. Then fields tagged with form, query, header or path are set
+// from the parsed form, the URL query, the request headers and the ServeMux path
+// values respectively, e.g.
+//
+//	type GetOrder struct {
+//		ID      int64  `path:"id"`
+//		Expand  []string `query:"expand"`
+//		TraceID string `header:"X-Trace-Id"`
+//	}
+//
+// Such fields may be strings, booleans, numbers, types implementing
+// encoding.TextUnmarshaler, slices of those for repeated values, or pointers to
+// any of them.
+func Bind(r *http.Request, dst any) error {
+	dstValue := reflect.ValueOf(dst)
+	if dstValue.Kind() != reflect.Pointer || dstValue.IsNil() || dstValue.Elem().Kind() != reflect.Struct {
+		panic(fmt.Sprintf("gomalhttp: Bind expects a pointer to a struct but got %T", dst))
+	}
+	structValue := dstValue.Elem()
+
+	if err := bindBody(r, dst); err != nil {
+		return err
+	}
+
+	for _, source := range bindSources {
+		for _, field := range reflect.VisibleFields(structValue.Type()) {
+			name, ok := field.Tag.Lookup(source)
+			if !ok || name == "-" || !field.IsExported() || field.Anonymous {
+				continue
+			}
+			values, err := lookup(r, source, name)
+			if err != nil {
+				return &BindError{Source: source, Err: err}
+			}
+			if len(values) < 1 {
+				continue
+			}
+			fieldValue, err := structValue.FieldByIndexErr(field.Index)
+			if err != nil {
+				continue
+			}
+			if err := setValue(fieldValue, values); err != nil {
+				return &BindError{Source: source, Name: name, Err: err}
+			}
+		}
+	}
+	return nil
+}
+
+func bindBody(r *http.Request, dst any) error {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil
+	}
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json") {
+		return nil
+	}
+
+	body := http.MaxBytesReader(nil, r.Body, MaxBodySize)
+	if err := json.NewDecoder(body).Decode(dst); err != nil && !errors.Is(err, io.EOF) {
+		return &BindError{Source: "body", Err: err}
+	}
+	return nil
+}
+
+func lookup(r *http.Request, source, name string) ([]string, error) {
+	switch source {
+	case "form":
+		if r.Form == nil {
+			if err := r.ParseMultipartForm(maxMemory); err != nil && !errors.Is(err, http.ErrNotMultipart) {
+				return nil, err
+			}
+		}
+		return r.PostForm[name], nil
+	case "query":
+		return r.URL.Query()[name], nil
+	case "header":
+		return r.Header.Values(name), nil
+	case "path":
+		if value := r.PathValue(name); value != "" {
+			return []string{value}, nil
+		}
+	}
+	return nil, nil
+}
+
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+func setValue(value reflect.Value, values []string) error {
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			value.Set(reflect.New(value.Type().Elem()))
+		}
+		return setValue(value.Elem(), values)
+	}
+	if reflect.PointerTo(value.Type()).Implements(textUnmarshalerType) {
+		return value.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(values[0]))
+	}
+	if value.Kind() == reflect.Slice {
+		slice := reflect.MakeSlice(value.Type(), len(values), len(values))
+		for i, item := range values {
+			if err := setValue(slice.Index(i), []string{item}); err != nil {
+				return err
+			}
+		}
+		value.Set(slice)
+		return nil
+	}
+
+	text := values[0]
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(text)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(text)
+		if err != nil {
+			return err
+		}
+		value.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(text, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(text, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(text, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetFloat(parsed)
+	default:
+		return fmt.Errorf("unsupported type %v", value.Type())
+	}
+	return nil
+}
@@ -0,0 +1,206 @@
+package gomalhttp_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ItsMalma/gomal"
+	"github.com/ItsMalma/gomal/gomalhttp"
+)
+
+type createOrder struct {
+	ID      int64    `path:"id"`
+	Expand  []string `query:"expand"`
+	TraceID string   `header:"X-Trace-Id" gomal:"notempty"`
+	SKU     string   `json:"sku" gomal:"notempty"`
+	Note    string   `form:"note"`
+}
+
+func TestBind(t *testing.T) {
+	var order createOrder
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /orders/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if err := gomalhttp.Bind(r, &order); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	request := httptest.NewRequest(http.MethodPost, "/orders/42?expand=items&expand=customer", strings.NewReader(`{"sku":"A-1"}`))
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("X-Trace-Id", "abc")
+	mux.ServeHTTP(httptest.NewRecorder(), request)
+
+	expected := createOrder{ID: 42, Expand: []string{"items", "customer"}, TraceID: "abc", SKU: "A-1"}
+	if !reflect.DeepEqual(order, expected) {
+		t.Fatalf("expected %#v but got %#v instead", expected, order)
+	}
+}
+
+func TestBindForm(t *testing.T) {
+	var order createOrder
+	request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(url.Values{"note": {"fragile"}}.Encode()))
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if err := gomalhttp.Bind(request, &order); err != nil {
+		t.Fatal(err)
+	}
+	if order.Note != "fragile" {
+		t.Fatalf("expected note to be bound but got %#v instead", order)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("POST /orders/{id}", gomalhttp.Handler(func(w http.ResponseWriter, r *http.Request, order *createOrder) {
+		w.Write([]byte(order.SKU))
+	}))
+
+	tests := []struct {
+		name        string
+		target      string
+		body        string
+		status      int
+		contentType string
+		response    string
+	}{
+		{
+			name:        "success",
+			target:      "/orders/1",
+			body:        `{"sku":"A-1"}`,
+			status:      http.StatusOK,
+			contentType: "text/plain; charset=utf-8",
+			response:    "A-1",
+		},
+		{
+			name:        "invalid",
+			target:      "/orders/1",
+			body:        `{"sku":""}`,
+			status:      http.StatusBadRequest,
+			contentType: "application/problem+json",
+			response:    `{"type":"about:blank","title":"Bad Request","status":400,"detail":"Your request parameters didn't validate.","invalid-params":[{"name":"sku","reason":"sku should not be empty."}]}` + "\n",
+		},
+		{
+			name:        "malformed path value",
+			target:      "/orders/x",
+			body:        `{"sku":"A-1"}`,
+			status:      http.StatusBadRequest,
+			contentType: "application/problem+json",
+			response:    `{"type":"about:blank","title":"Bad Request","status":400,"detail":"Your request could not be decoded.","invalid-params":[{"name":"id","reason":"must be an integer"}]}` + "\n",
+		},
+		{
+			name:        "malformed body",
+			target:      "/orders/1",
+			body:        `{`,
+			status:      http.StatusBadRequest,
+			contentType: "application/problem+json",
+			response:    `{"type":"about:blank","title":"Bad Request","status":400,"detail":"Your request body is not valid JSON."}` + "\n",
+		},
+		{
+			name:        "mistyped body field",
+			target:      "/orders/1",
+			body:        `{"sku":12}`,
+			status:      http.StatusBadRequest,
+			contentType: "application/problem+json",
+			response:    `{"type":"about:blank","title":"Bad Request","status":400,"detail":"Your request could not be decoded.","invalid-params":[{"name":"sku","reason":"must be a string"}]}` + "\n",
+		},
+		{
+			name:        "path value out of range",
+			target:      "/orders/99999999999999999999",
+			body:        `{"sku":"A-1"}`,
+			status:      http.StatusBadRequest,
+			contentType: "application/problem+json",
+			response:    `{"type":"about:blank","title":"Bad Request","status":400,"detail":"Your request could not be decoded.","invalid-params":[{"name":"id","reason":"is out of range"}]}` + "\n",
+		},
+		{
+			name:        "body too large",
+			target:      "/orders/1",
+			body:        `{"sku":"` + strings.Repeat("A", 1<<20) + `"}`,
+			status:      http.StatusRequestEntityTooLarge,
+			contentType: "application/problem+json",
+			response:    `{"type":"about:blank","title":"Request Entity Too Large","status":413,"detail":"Your request body must not be larger than 1048576 bytes."}` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			request := httptest.NewRequest(http.MethodPost, test.target, strings.NewReader(test.body))
+			request.Header.Set("Content-Type", "application/json")
+			request.Header.Set("X-Trace-Id", "abc")
+			recorder := httptest.NewRecorder()
+			mux.ServeHTTP(recorder, request)
+
+			if recorder.Code != test.status {
+				tt.Fatalf("expected status %v but got %v instead", test.status, recorder.Code)
+			}
+			if contentType := recorder.Header().Get("Content-Type"); contentType != test.contentType {
+				tt.Fatalf("expected content type %q but got %q instead", test.contentType, contentType)
+			}
+			if recorder.Body.String() != test.response {
+				tt.Fatalf("expected %s but got %s instead", test.response, recorder.Body.String())
+			}
+		})
+	}
+}
+
+type tenantKey struct{}
+
+type booking struct {
+	Room string `json:"room"`
+}
+
+func (b booking) ValidateContext(ctx context.Context) []gomal.ValidationResult {
+	if taken, _ := ctx.Value(tenantKey{}).(string); taken == b.Room {
+		return []gomal.ValidationResult{{Name: "room", Messages: []string{"room is already booked."}}}
+	}
+	return nil
+}
+
+func TestMiddlewareContext(t *testing.T) {
+	handler := gomalhttp.Handler(func(w http.ResponseWriter, r *http.Request, value *booking) {
+		w.Write([]byte(value.Room))
+	})
+
+	ctx := context.WithValue(context.Background(), tenantKey{}, "101")
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		status   int
+		response string
+	}{
+		{
+			name:     "booked",
+			ctx:      ctx,
+			status:   http.StatusBadRequest,
+			response: `{"type":"about:blank","title":"Bad Request","status":400,"detail":"Your request parameters didn't validate.","invalid-params":[{"name":"room","reason":"room is already booked."}]}` + "\n",
+		},
+		{
+			name:     "cancelled",
+			ctx:      cancelled,
+			status:   http.StatusServiceUnavailable,
+			response: `{"type":"about:blank","title":"Service Unavailable","status":503,"detail":"Your request could not be validated before it was cancelled."}` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			request := httptest.NewRequest(http.MethodPost, "/bookings", strings.NewReader(`{"room":"101"}`)).WithContext(test.ctx)
+			request.Header.Set("Content-Type", "application/json")
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+
+			if recorder.Code != test.status {
+				tt.Fatalf("expected status %v but got %v instead", test.status, recorder.Code)
+			}
+			if recorder.Body.String() != test.response {
+				tt.Fatalf("expected %s but got %s instead", test.response, recorder.Body.String())
+			}
+		})
+	}
+}
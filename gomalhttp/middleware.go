@@ -0,0 +1,49 @@
+package gomalhttp
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ItsMalma/gomal"
+)
+
+type contextKey[T any] struct{}
+
+// Middleware binds every request into a T with Bind and validates it with
+// gomal.ValidateStructContext and the request's context, whose translator
+// renders the messages. Failures are answered with a problem response, as is a
+// request whose context is done before it is validated, otherwise next is
+// called with the value available through Value.
+func Middleware[T any](next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		value := new(T)
+		if err := Bind(r, value); err != nil {
+			WriteProblem(w, BindProblem(err))
+			return
+		}
+		results, err := gomal.ValidateStructContext(r.Context(), value)
+		if err != nil {
+			WriteProblem(w, newProblem(http.StatusServiceUnavailable, "Your request could not be validated before it was cancelled."))
+			return
+		}
+		if len(results) > 0 {
+			WriteProblem(w, ValidationProblem(results))
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), contextKey[T]{}, value)))
+	})
+}
+
+// Handler is Middleware calling handle with the bound value directly.
+func Handler[T any](handle func(w http.ResponseWriter, r *http.Request, value *T)) http.Handler {
+	return Middleware[T](http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handle(w, r, Value[T](r))
+	}))
+}
+
+// Value returns the value bound by Middleware for r, or nil when r did not go
+// through Middleware[T].
+func Value[T any](r *http.Request) *T {
+	value, _ := r.Context().Value(contextKey[T]{}).(*T)
+	return value
+}
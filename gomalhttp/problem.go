@@ -0,0 +1,129 @@
+package gomalhttp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+
+	"github.com/ItsMalma/gomal"
+)
+
+// Problem is an RFC 7807 problem details object.
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	// InvalidParams is the "invalid-params" extension member from the RFC's
+	// validation example, one entry per failed rule.
+	InvalidParams []InvalidParam `json:"invalid-params,omitempty"`
+}
+
+type InvalidParam struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// ValidationProblem returns a 400 Bad Request problem listing every message of
+// results as an invalid param.
+func ValidationProblem(results []gomal.ValidationResult) Problem {
+	problem := newProblem(http.StatusBadRequest, "Your request parameters didn't validate.")
+	for _, result := range results {
+		for _, message := range result.Messages {
+			problem.InvalidParams = append(problem.InvalidParams, InvalidParam{Name: result.Name, Reason: message})
+		}
+	}
+	return problem
+}
+
+// BindProblem returns a 400 Bad Request problem for an error returned by Bind,
+// or a 413 Request Entity Too Large one when the body exceeds MaxBodySize. The
+// problem states what is wrong in generic terms, such as "must be an integer",
+// without the text of err, which names Go types and parsers; log err to
+// diagnose the request.
+func BindProblem(err error) Problem {
+	var (
+		bindErr *BindError
+		typeErr *json.UnmarshalTypeError
+		sizeErr *http.MaxBytesError
+	)
+	if errors.As(err, &sizeErr) {
+		return newProblem(http.StatusRequestEntityTooLarge, fmt.Sprintf("Your request body must not be larger than %v bytes.", sizeErr.Limit))
+	}
+
+	problem := newProblem(http.StatusBadRequest, "Your request could not be decoded.")
+	switch {
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		problem.InvalidParams = []InvalidParam{{Name: typeErr.Field, Reason: typeReason(typeErr.Type)}}
+	case errors.As(err, &bindErr) && bindErr.Name != "":
+		problem.InvalidParams = []InvalidParam{{Name: bindErr.Name, Reason: parseReason(bindErr.Err)}}
+	case errors.As(err, &bindErr) && bindErr.Source == "body":
+		problem.Detail = "Your request body is not valid JSON."
+	}
+	return problem
+}
+
+// parseReason describes why a parameter could not be parsed by Bind.
+func parseReason(err error) string {
+	var numErr *strconv.NumError
+	if errors.As(err, &numErr) {
+		if errors.Is(numErr.Err, strconv.ErrRange) {
+			return "is out of range"
+		}
+		switch numErr.Func {
+		case "ParseBool":
+			return "must be a boolean"
+		case "ParseInt":
+			return "must be an integer"
+		case "ParseUint":
+			return "must be a non-negative integer"
+		case "ParseFloat":
+			return "must be a number"
+		}
+	}
+	return "is not a valid value"
+}
+
+// typeReason describes the JSON value expected for a field of fieldType.
+func typeReason(fieldType reflect.Type) string {
+	for fieldType.Kind() == reflect.Pointer {
+		fieldType = fieldType.Elem()
+	}
+	switch fieldType.Kind() {
+	case reflect.Bool:
+		return "must be a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "must be an integer"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "must be a non-negative integer"
+	case reflect.Float32, reflect.Float64:
+		return "must be a number"
+	case reflect.String:
+		return "must be a string"
+	case reflect.Array, reflect.Slice:
+		return "must be an array"
+	case reflect.Map, reflect.Struct:
+		return "must be an object"
+	}
+	return "is not a valid value"
+}
+
+func newProblem(status int, detail string) Problem {
+	return Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	}
+}
+
+// WriteProblem writes problem as an application/problem+json response.
+func WriteProblem(w http.ResponseWriter, problem Problem) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(problem.Status)
+	json.NewEncoder(w).Encode(problem)
+}
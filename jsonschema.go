@@ -0,0 +1,367 @@
+package gomal
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// JSONSchemaDialect is the JSON Schema draft produced by the JSONSchema
+// functions.
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// jsonSchemaKeywords maps rule codes to the JSON Schema keywords they set. Rules
+// without an entry, such as custom callbacks, are not exported.
+var jsonSchemaKeywords = map[string]func(schema map[string]any, params map[string]any){
+	"notempty": func(schema map[string]any, params map[string]any) {
+		switch schema["type"] {
+		case "string":
+			schema["minLength"] = 1
+		case "array":
+			schema["minItems"] = 1
+		case "object":
+			schema["minProperties"] = 1
+		}
+	},
+	"equal": func(schema map[string]any, params map[string]any) {
+		schema["const"] = params["other"]
+	},
+	"notequal": func(schema map[string]any, params map[string]any) {
+		schema["not"] = map[string]any{"const": params["other"]}
+	},
+	"length": func(schema map[string]any, params map[string]any) {
+		schema["minLength"] = params["min"]
+		schema["maxLength"] = params["max"]
+	},
+	"minlength": func(schema map[string]any, params map[string]any) {
+		schema["minLength"] = params["min"]
+	},
+	"maxlength": func(schema map[string]any, params map[string]any) {
+		schema["maxLength"] = params["max"]
+	},
+	"lessthan": func(schema map[string]any, params map[string]any) {
+		setJSONSchemaNumber(schema, "exclusiveMaximum", params["limit"])
+	},
+	"lessthanorequal": func(schema map[string]any, params map[string]any) {
+		setJSONSchemaNumber(schema, "maximum", params["limit"])
+	},
+	"greaterthan": func(schema map[string]any, params map[string]any) {
+		setJSONSchemaNumber(schema, "exclusiveMinimum", params["limit"])
+	},
+	"greaterthanorequal": func(schema map[string]any, params map[string]any) {
+		setJSONSchemaNumber(schema, "minimum", params["limit"])
+	},
+	"between": func(schema map[string]any, params map[string]any) {
+		setJSONSchemaNumber(schema, "minimum", params["min"])
+		setJSONSchemaNumber(schema, "maximum", params["max"])
+	},
+	"regexp": func(schema map[string]any, params map[string]any) {
+		schema["pattern"] = params["pattern"]
+	},
+	"email": func(schema map[string]any, params map[string]any) {
+		schema["format"] = "email"
+	},
+	"strictemail": func(schema map[string]any, params map[string]any) {
+		schema["format"] = "email"
+	},
+	"oneof": func(schema map[string]any, params map[string]any) {
+		if values, ok := params["values"]; ok {
+			schema["enum"] = values
+		}
+	},
+	"notoneof": func(schema map[string]any, params map[string]any) {
+		schema["not"] = map[string]any{"enum": params["values"]}
+	},
+	"positive": func(schema map[string]any, params map[string]any) {
+		setJSONSchemaNumber(schema, "exclusiveMinimum", 0)
+	},
+	"negative": func(schema map[string]any, params map[string]any) {
+		setJSONSchemaNumber(schema, "exclusiveMaximum", 0)
+	},
+	"nonzero": func(schema map[string]any, params map[string]any) {
+		schema["not"] = map[string]any{"const": 0}
+	},
+	"multipleof": func(schema map[string]any, params map[string]any) {
+		setJSONSchemaNumber(schema, "multipleOf", params["step"])
+	},
+	"int": func(schema map[string]any, params map[string]any) {
+		schema["pattern"] = `^[+-]?[0-9]+$`
+	},
+	"url":         jsonSchemaFormat("uri"),
+	"uuid":        jsonSchemaFormat("uuid"),
+	"ipv4":        jsonSchemaFormat("ipv4"),
+	"ipv6":        jsonSchemaFormat("ipv6"),
+	"hostname":    jsonSchemaFormat("hostname"),
+	"fqdn":        jsonSchemaFormat("hostname"),
+	"iso8601date": jsonSchemaFormat("date"),
+	"rfc3339":     jsonSchemaFormat("date-time"),
+	"e164phone":   jsonSchemaPattern(e164Regexp),
+	"hex":         jsonSchemaPattern(hexRegexp),
+	"semver":      jsonSchemaPattern(semverRegexp),
+	"slug":        jsonSchemaPattern(slugRegexp),
+	"minitems": func(schema map[string]any, params map[string]any) {
+		if schema["type"] == "object" {
+			schema["minProperties"] = params["min"]
+		} else {
+			schema["minItems"] = params["min"]
+		}
+	},
+	"maxitems": func(schema map[string]any, params map[string]any) {
+		if schema["type"] == "object" {
+			schema["maxProperties"] = params["max"]
+		} else {
+			schema["maxItems"] = params["max"]
+		}
+	},
+	"unique": func(schema map[string]any, params map[string]any) {
+		if params["key"] == nil {
+			schema["uniqueItems"] = true
+		}
+	},
+	"contains": func(schema map[string]any, params map[string]any) {
+		if schema["type"] == "array" {
+			schema["contains"] = map[string]any{"const": params["value"]}
+		}
+	},
+	"subset": func(schema map[string]any, params map[string]any) {
+		item, ok := schema["items"].(map[string]any)
+		if !ok {
+			item = map[string]any{}
+			schema["items"] = item
+		}
+		item["enum"] = params["values"]
+	},
+	"base64": func(schema map[string]any, params map[string]any) {
+		schema["contentEncoding"] = "base64"
+	},
+	"json": func(schema map[string]any, params map[string]any) {
+		schema["contentMediaType"] = "application/json"
+	},
+}
+
+func jsonSchemaFormat(format string) func(schema map[string]any, params map[string]any) {
+	return func(schema map[string]any, params map[string]any) {
+		schema["format"] = format
+	}
+}
+
+// setJSONSchemaNumber sets the numeric keyword to value, unless the schema is not
+// about numbers, like the one of a string holding a decimal amount, which the
+// keyword would not constrain. Decimal strings, as parsed from the tags of such
+// fields, are written as JSON numbers.
+func setJSONSchemaNumber(schema map[string]any, keyword string, value any) {
+	if schemaType, ok := schema["type"]; ok && schemaType != "integer" && schemaType != "number" {
+		return
+	}
+	if decimal, ok := value.(string); ok {
+		scale := 0
+		if _, fraction, found := strings.Cut(decimal, "."); found {
+			scale = len(fraction)
+		}
+		rat, _ := new(big.Rat).SetString(decimal)
+		value = json.Number(rat.FloatString(scale))
+	}
+	schema[keyword] = value
+}
+
+func jsonSchemaPattern(pattern *regexp.Regexp) func(schema map[string]any, params map[string]any) {
+	return func(schema map[string]any, params map[string]any) {
+		schema["pattern"] = pattern.String()
+	}
+}
+
+// StructJSONSchema returns the JSON Schema (draft 2020-12) of the type of v, a
+// struct or a pointer to one, with the constraints declared in its gomal tags,
+// e.g. length=3|64 becomes "minLength": 3, "maxLength": 64. Property names
+// follow the json tags. The result can be marshaled with encoding/json, for
+// instance into the components.schemas of an OpenAPI document.
+func StructJSONSchema(v any) map[string]any {
+	structType := reflect.TypeOf(v)
+	for structType != nil && structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+	if structType == nil || structType.Kind() != reflect.Struct {
+		panic("gomal: StructJSONSchema expects a struct")
+	}
+
+	schema := typeJSONSchema(structType, map[reflect.Type]bool{})
+	schema["$schema"] = JSONSchemaDialect
+	return schema
+}
+
+// JSONSchema returns the JSON Schema (draft 2020-12) of the schema, see
+// StructJSONSchema. Property types are inferred from what the field getters
+// return for the zero value of T.
+func (schema *ObjectSchema[T]) JSONSchema() map[string]any {
+	var zero T
+	properties := map[string]any{}
+	required := []string{}
+	for _, field := range schema.fields {
+		property := map[string]any{}
+		if fieldType := zeroFieldType(field.get, zero); fieldType != nil {
+			property = typeJSONSchema(fieldType, map[reflect.Type]bool{})
+		}
+		if applyJSONSchema(property, field.Rules) {
+			required = append(required, field.Name)
+		}
+		properties[field.Name] = property
+	}
+
+	jsonSchema := map[string]any{
+		"$schema":    JSONSchemaDialect,
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		jsonSchema["required"] = required
+	}
+	return jsonSchema
+}
+
+// zeroFieldType returns the type returned by get for zero, or nil when get
+// returns nil or panics.
+func zeroFieldType[T any](get func(value T) any, zero T) (fieldType reflect.Type) {
+	defer func() {
+		if recover() != nil {
+			fieldType = nil
+		}
+	}()
+	return reflect.TypeOf(get(zero))
+}
+
+// applyJSONSchema sets the keywords of rules on schema and reports whether they
+// make the property required.
+func applyJSONSchema(schema map[string]any, rules []Rule) bool {
+	required := false
+	for _, rule := range rules {
+		switch rule.Code() {
+		case "notnil", "notempty":
+			required = true
+		case "dive":
+			applyItemsJSONSchema(schema, rule.Params())
+		case "anyof", "allof", "exactlyoneof":
+			rules, _ := rule.Params()["rules"].([]Rule)
+			keyword := map[string]string{"anyof": "anyOf", "allof": "allOf", "exactlyoneof": "oneOf"}[rule.Code()]
+			subschemas := make([]any, len(rules))
+			for i, subrule := range rules {
+				subschema := map[string]any{}
+				applyJSONSchema(subschema, []Rule{subrule})
+				subschemas[i] = subschema
+			}
+			schema[keyword] = subschemas
+		case "not":
+			if negated, ok := rule.Params()["rule"].(Rule); ok {
+				subschema := map[string]any{}
+				applyJSONSchema(subschema, []Rule{negated})
+				schema["not"] = subschema
+			}
+		case "keys":
+			keys := map[string]any{"type": "string"}
+			rules, _ := rule.Params()["rules"].([]Rule)
+			applyJSONSchema(keys, rules)
+			schema["propertyNames"] = keys
+		}
+		if apply, ok := jsonSchemaKeywords[rule.Code()]; ok {
+			apply(schema, rule.Params())
+		}
+	}
+	return required
+}
+
+// applyItemsJSONSchema sets the keywords of the rules of a dive on the items of
+// an array or the values of an object.
+func applyItemsJSONSchema(schema map[string]any, params map[string]any) {
+	rules, _ := params["rules"].([]Rule)
+	keyword := "items"
+	if schema["type"] == "object" {
+		keyword = "additionalProperties"
+	}
+	item, ok := schema[keyword].(map[string]any)
+	if !ok {
+		item = map[string]any{}
+		schema[keyword] = item
+	}
+	applyJSONSchema(item, rules)
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// typeJSONSchema describes values of fieldType. visiting holds the structs
+// being described, so recursive types end in a bare object.
+func typeJSONSchema(fieldType reflect.Type, visiting map[reflect.Type]bool) map[string]any {
+	for fieldType.Kind() == reflect.Pointer {
+		fieldType = fieldType.Elem()
+	}
+
+	if fieldType == timeType {
+		return map[string]any{"type": "string", "format": "date-time"}
+	}
+	if fieldType == bigIntType {
+		return map[string]any{"type": "integer"}
+	}
+	switch fieldType.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Array, reflect.Slice:
+		if fieldType.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string", "contentEncoding": "base64"}
+		}
+		return map[string]any{"type": "array", "items": typeJSONSchema(fieldType.Elem(), visiting)}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": typeJSONSchema(fieldType.Elem(), visiting)}
+	case reflect.Struct:
+		if visiting[fieldType] {
+			return map[string]any{"type": "object"}
+		}
+		visiting[fieldType] = true
+		defer delete(visiting, fieldType)
+		return structJSONSchema(fieldType, visiting)
+	}
+	return map[string]any{}
+}
+
+func structJSONSchema(structType reflect.Type, visiting map[reflect.Type]bool) map[string]any {
+	properties := map[string]any{}
+	required := []string{}
+	for _, field := range reflect.VisibleFields(structType) {
+		if !field.IsExported() || field.Anonymous {
+			continue
+		}
+		jsonName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		tag := field.Tag.Get("gomal")
+		if jsonName == "-" || tag == "-" {
+			continue
+		}
+
+		rules, _, err := parseTag(tag, field.Type)
+		if err != nil {
+			panic(fmt.Sprintf("gomal: invalid tag on %v.%v: %v", structType, field.Name, err))
+		}
+		name := fieldName(field)
+		property := typeJSONSchema(field.Type, visiting)
+		if applyJSONSchema(property, rules) {
+			required = append(required, name)
+		}
+		properties[name] = property
+	}
+
+	schema := map[string]any{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
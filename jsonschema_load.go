@@ -0,0 +1,392 @@
+package gomal
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"math"
+	"net/url"
+	"path"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// JSONSchema validates decoded JSON documents against a JSON Schema. It
+// supports the type, required, properties, items, enum, const, minimum,
+// maximum, exclusiveMinimum, exclusiveMaximum, minLength, maxLength, pattern,
+// format and $ref keywords; other keywords are ignored.
+type JSONSchema struct {
+	root *jsonSchemaNode
+}
+
+type jsonSchemaNode struct {
+	types            []string
+	required         []string
+	properties       map[string]*jsonSchemaNode
+	items            *jsonSchemaNode
+	enum             []any
+	constant         *any
+	minimum          *float64
+	maximum          *float64
+	exclusiveMinimum *float64
+	exclusiveMaximum *float64
+	minLength        *int
+	maxLength        *int
+	pattern          *regexp.Regexp
+	format           string
+	ref              *jsonSchemaNode
+}
+
+// jsonSchemaFormats checks the values of the supported format keywords.
+var jsonSchemaFormats = map[string]func(value string) bool{
+	"date-time": isRFC3339,
+	"date":      isISO8601Date,
+	"uri": func(value string) bool {
+		parsed, err := url.Parse(value)
+		return err == nil && parsed.Scheme != ""
+	},
+	"uuid":     func(value string) bool { return isUUID(value, 0) },
+	"ipv4":     isIPv4,
+	"ipv6":     isIPv6,
+	"hostname": isHostname,
+}
+
+// CompileJSONSchema compiles a JSON Schema document. Its $ref values may only
+// point inside the document itself, e.g. "#/$defs/address"; use LoadJSONSchema
+// for schemas split across files.
+func CompileJSONSchema(data []byte) (*JSONSchema, error) {
+	var document any
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("gomal: invalid JSON Schema: %w", err)
+	}
+	loader := &jsonSchemaLoader{documents: map[string]any{"": document}, nodes: map[string]*jsonSchemaNode{}}
+	root, err := loader.compile("", document)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONSchema{root: root}, nil
+}
+
+// LoadJSONSchema compiles the JSON Schema file name from fsys, typically an
+// os.DirFS. $ref values are resolved relative to the referencing file and only
+// against files of fsys; remote references are rejected.
+func LoadJSONSchema(fsys fs.FS, name string) (*JSONSchema, error) {
+	loader := &jsonSchemaLoader{fsys: fsys, documents: map[string]any{}, nodes: map[string]*jsonSchemaNode{}}
+	root, err := loader.resolve(name, "")
+	if err != nil {
+		return nil, err
+	}
+	return &JSONSchema{root: root}, nil
+}
+
+// Validate validates value, as decoded by encoding/json, against the schema.
+// The properties of the document are reported by their path, such as
+// "items[3].sku". Failures of the document itself are reported with an empty
+// name.
+func (schema *JSONSchema) Validate(value any) []ValidationResult {
+	return Validate(schema.Validator("", value))
+}
+
+// Validator validates value against the schema, reporting it as name.
+func (schema *JSONSchema) Validator(name string, value any) Validator {
+	return schema.root.validate(If(name, value))
+}
+
+type jsonSchemaLoader struct {
+	fsys      fs.FS
+	documents map[string]any
+	// nodes holds the compiled nodes by "file#pointer", so recursive references
+	// share a node.
+	nodes map[string]*jsonSchemaNode
+}
+
+// resolve compiles the schema at the JSON pointer fragment of file.
+func (loader *jsonSchemaLoader) resolve(file, fragment string) (*jsonSchemaNode, error) {
+	key := file + "#" + fragment
+	if node, ok := loader.nodes[key]; ok {
+		return node, nil
+	}
+
+	document, ok := loader.documents[file]
+	if !ok {
+		if loader.fsys == nil {
+			return nil, fmt.Errorf("gomal: cannot resolve $ref to %q without a file system", file)
+		}
+		data, err := fs.ReadFile(loader.fsys, file)
+		if err != nil {
+			return nil, fmt.Errorf("gomal: cannot load JSON Schema: %w", err)
+		}
+		if err := json.Unmarshal(data, &document); err != nil {
+			return nil, fmt.Errorf("gomal: invalid JSON Schema %v: %w", file, err)
+		}
+		loader.documents[file] = document
+	}
+
+	for _, token := range strings.Split(strings.TrimPrefix(fragment, "/"), "/") {
+		if token == "" {
+			continue
+		}
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		object, ok := document.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("gomal: cannot resolve $ref %v", key)
+		}
+		if document, ok = object[token]; !ok {
+			return nil, fmt.Errorf("gomal: cannot resolve $ref %v", key)
+		}
+	}
+
+	node := &jsonSchemaNode{}
+	loader.nodes[key] = node
+	compiled, err := loader.compile(file, document)
+	if err != nil {
+		return nil, err
+	}
+	*node = *compiled
+	return node, nil
+}
+
+// compile compiles a schema found in file.
+func (loader *jsonSchemaLoader) compile(file string, document any) (*jsonSchemaNode, error) {
+	if document == true {
+		return &jsonSchemaNode{}, nil
+	}
+	object, ok := document.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("gomal: unsupported JSON Schema %v in %q", document, file)
+	}
+
+	node := &jsonSchemaNode{}
+	if ref, ok := object["$ref"].(string); ok {
+		refFile, fragment, _ := strings.Cut(ref, "#")
+		if strings.Contains(refFile, "://") {
+			return nil, fmt.Errorf("gomal: remote $ref %q is not supported", ref)
+		}
+		if refFile == "" {
+			refFile = file
+		} else {
+			refFile = path.Join(path.Dir(file), refFile)
+		}
+		resolved, err := loader.resolve(refFile, fragment)
+		if err != nil {
+			return nil, err
+		}
+		node.ref = resolved
+	}
+
+	switch types := object["type"].(type) {
+	case string:
+		node.types = []string{types}
+	case []any:
+		for _, t := range types {
+			if t, ok := t.(string); ok {
+				node.types = append(node.types, t)
+			}
+		}
+	}
+	if required, ok := object["required"].([]any); ok {
+		for _, name := range required {
+			if name, ok := name.(string); ok {
+				node.required = append(node.required, name)
+			}
+		}
+	}
+	if properties, ok := object["properties"].(map[string]any); ok {
+		node.properties = make(map[string]*jsonSchemaNode, len(properties))
+		for name, property := range properties {
+			compiled, err := loader.compile(file, property)
+			if err != nil {
+				return nil, err
+			}
+			node.properties[name] = compiled
+		}
+	}
+	if items, ok := object["items"]; ok {
+		compiled, err := loader.compile(file, items)
+		if err != nil {
+			return nil, err
+		}
+		node.items = compiled
+	}
+	if enum, ok := object["enum"].([]any); ok {
+		node.enum = enum
+	}
+	if constant, ok := object["const"]; ok {
+		node.constant = &constant
+	}
+	node.minimum = jsonSchemaNumber(object["minimum"])
+	node.maximum = jsonSchemaNumber(object["maximum"])
+	node.exclusiveMinimum = jsonSchemaNumber(object["exclusiveMinimum"])
+	node.exclusiveMaximum = jsonSchemaNumber(object["exclusiveMaximum"])
+	if minLength := jsonSchemaNumber(object["minLength"]); minLength != nil {
+		length := int(*minLength)
+		node.minLength = &length
+	}
+	if maxLength := jsonSchemaNumber(object["maxLength"]); maxLength != nil {
+		length := int(*maxLength)
+		node.maxLength = &length
+	}
+	if pattern, ok := object["pattern"].(string); ok {
+		compiled, err := compileRegexp(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("gomal: invalid JSON Schema: %w", err)
+		}
+		node.pattern = compiled
+	}
+	if format, ok := object["format"].(string); ok {
+		node.format = format
+	}
+	return node, nil
+}
+
+func jsonSchemaNumber(value any) *float64 {
+	if number, ok := value.(float64); ok {
+		return &number
+	}
+	return nil
+}
+
+// validate applies the node to validator and its nested properties and items.
+func (node *jsonSchemaNode) validate(validator Validator) Validator {
+	if number, ok := validator.value.(json.Number); ok {
+		if parsed, err := number.Float64(); err == nil {
+			validator = If(validator.name, parsed)
+		}
+	}
+	if node.ref != nil {
+		validator = node.ref.validate(validator)
+	}
+	if validator.stop {
+		return validator
+	}
+
+	if len(node.types) > 0 && !node.matchesType(validator.value) {
+		var types any = node.types[0]
+		if len(node.types) > 1 {
+			list := make([]any, len(node.types))
+			for i, t := range node.types {
+				list[i] = t
+			}
+			types = list
+		}
+		validator.addViolation("type", map[string]any{"type": types}, "", nil)
+		return validator.When(false)
+	}
+
+	if node.constant != nil {
+		validator = validator.Equal(*node.constant)
+	}
+	if node.enum != nil && !containsValue(node.enum, validator.value) {
+		validator.addViolation("oneof", map[string]any{"values": node.enum}, "", nil)
+	}
+
+	switch value := validator.value.(type) {
+	case float64:
+		if node.minimum != nil {
+			validator = validator.GreaterThanOrEqual(*node.minimum)
+		}
+		if node.maximum != nil {
+			validator = validator.LessThanOrEqual(*node.maximum)
+		}
+		if node.exclusiveMinimum != nil {
+			validator = validator.GreaterThan(*node.exclusiveMinimum)
+		}
+		if node.exclusiveMaximum != nil {
+			validator = validator.LessThan(*node.exclusiveMaximum)
+		}
+	case string:
+		if node.minLength != nil {
+			validator = validator.MinLength(*node.minLength)
+		}
+		if node.maxLength != nil {
+			validator = validator.MaxLength(*node.maxLength)
+		}
+		if node.pattern != nil {
+			validator = validator.RegExp(node.pattern)
+		}
+		if node.format == "email" {
+			validator = validator.Email()
+		} else if check, ok := jsonSchemaFormats[node.format]; ok && !check(value) {
+			validator.addViolation("format", map[string]any{"format": node.format}, "", nil)
+		}
+	case map[string]any:
+		names := append([]string{}, node.required...)
+		for name := range node.properties {
+			if !containsString(node.required, name) {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			property, ok := value[name]
+			if !ok {
+				if containsString(node.required, name) {
+					required := If(fieldPath(validator.name, name), nil)
+					required.addViolation("required", nil, "", nil)
+					validator.children = append(validator.children, required)
+				}
+				continue
+			}
+			if propertyNode, ok := node.properties[name]; ok {
+				validator.children = append(validator.children, propertyNode.validate(If(fieldPath(validator.name, name), property)))
+			}
+		}
+	case []any:
+		if node.items != nil {
+			validator = validator.Dive(node.items.validate)
+		}
+	}
+	return validator
+}
+
+func (node *jsonSchemaNode) matchesType(value any) bool {
+	for _, t := range node.types {
+		switch value := value.(type) {
+		case nil:
+			if t == "null" {
+				return true
+			}
+		case bool:
+			if t == "boolean" {
+				return true
+			}
+		case float64:
+			if t == "number" || (t == "integer" && value == math.Trunc(value)) {
+				return true
+			}
+		case string:
+			if t == "string" {
+				return true
+			}
+		case []any:
+			if t == "array" {
+				return true
+			}
+		case map[string]any:
+			if t == "object" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, allowed := range values {
+		if allowed == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsValue(values []any, value any) bool {
+	for _, allowed := range values {
+		if reflect.DeepEqual(allowed, value) {
+			return true
+		}
+	}
+	return false
+}
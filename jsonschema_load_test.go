@@ -0,0 +1,86 @@
+package gomal_test
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/ItsMalma/gomal"
+)
+
+func TestLoadJSONSchema(t *testing.T) {
+	schema, err := gomal.LoadJSONSchema(os.DirFS("testdata"), "webhook/order.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		payload string
+		results []gomal.ValidationResult
+	}{
+		{
+			name:    "success",
+			payload: `{"id":"8c1b6a5e-2f7d-4c11-9d0e-3f1f0b7a2c44","status":"paid","total":10,"items":[{"sku":"A-1","quantity":2}],"customer":{"name":"Malma"}}`,
+			results: []gomal.ValidationResult{},
+		},
+		{
+			name:    "failed",
+			payload: `{"id":"nope","status":"refunded","email":"x","total":-1,"items":[{"sku":"a1","quantity":1.5},{"quantity":0}],"customer":{"name":"M"}}`,
+			results: []gomal.ValidationResult{
+				{Name: "customer.name", Messages: []string{"The length of customer.name must be at least 2 characters. You entered 1 characters."}},
+				{Name: "email", Messages: []string{"email is not a valid email address"}},
+				{Name: "id", Messages: []string{"id must be a valid uuid."}},
+				{Name: "items[0].quantity", Messages: []string{"items[0].quantity must be of type integer."}},
+				{Name: "items[0].sku", Messages: []string{"items[0].sku is not in the correct format"}},
+				{Name: "items[1].quantity", Messages: []string{"items[1].quantity must be greater than 0."}},
+				{Name: "items[1].sku", Messages: []string{"items[1].sku is required."}},
+				{Name: "status", Messages: []string{"status must be one of draft, paid."}},
+				{Name: "total", Messages: []string{"total must be greater than or equal to 0."}},
+			},
+		},
+		{
+			name:    "missing required",
+			payload: `{"status":"draft"}`,
+			results: []gomal.ValidationResult{
+				{Name: "id", Messages: []string{"id is required."}},
+				{Name: "items", Messages: []string{"items is required."}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			var payload map[string]any
+			if err := json.Unmarshal([]byte(test.payload), &payload); err != nil {
+				tt.Fatal(err)
+			}
+			results := schema.Validate(payload)
+			if !reflect.DeepEqual(results, test.results) {
+				tt.Fatalf("expected %#v but got %#v instead", test.results, results)
+			}
+		})
+	}
+}
+
+func TestCompileJSONSchema(t *testing.T) {
+	if _, err := gomal.CompileJSONSchema([]byte(`{"$ref":"https://example.com/schema.json"}`)); err == nil {
+		t.Fatalf("expected remote $ref to be rejected")
+	}
+	if _, err := gomal.CompileJSONSchema([]byte(`{"$ref":"other.json"}`)); err == nil {
+		t.Fatalf("expected file $ref to be rejected without a file system")
+	}
+
+	schema, err := gomal.CompileJSONSchema([]byte(`{"type":["string","null"],"maxLength":3}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []gomal.ValidationResult{{Name: "code", Messages: []string{"code must be of type string, null."}}}
+	if results := gomal.Validate(schema.Validator("code", 1.0)); !reflect.DeepEqual(results, expected) {
+		t.Fatalf("expected %#v but got %#v instead", expected, results)
+	}
+	if results := gomal.Validate(schema.Validator("code", nil)); !reflect.DeepEqual(results, []gomal.ValidationResult{}) {
+		t.Fatalf("expected empty but got %#v instead", results)
+	}
+}
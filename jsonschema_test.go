@@ -0,0 +1,87 @@
+package gomal_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ItsMalma/gomal"
+)
+
+func TestStructJSONSchema(t *testing.T) {
+	type address struct {
+		City string `json:"city" gomal:"notempty"`
+	}
+	type account struct {
+		Email    string            `json:"email" gomal:"notempty,email,length=3|64"`
+		Username string            `json:"username" gomal:"regexp=^[a-z]+$"`
+		Age      int               `json:"age" gomal:"greaterthan=16,lessthanorequal=120"`
+		Score    float64           `json:"score" gomal:"between=0|1"`
+		Tags     []string          `json:"tags" gomal:"dive,maxlength=10"`
+		Labels   map[string]string `json:"labels"`
+		Address  *address          `json:"address" gomal:"notnil"`
+		Secret   string            `json:"-"`
+	}
+
+	data, err := json.Marshal(gomal.StructJSONSchema(&account{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"$schema":"https://json-schema.org/draft/2020-12/schema","properties":{` +
+		`"address":{"properties":{"city":{"minLength":1,"type":"string"}},"required":["city"],"type":"object"},` +
+		`"age":{"exclusiveMinimum":16,"maximum":120,"type":"integer"},` +
+		`"email":{"format":"email","maxLength":64,"minLength":3,"type":"string"},` +
+		`"labels":{"additionalProperties":{"type":"string"},"type":"object"},` +
+		`"score":{"maximum":1,"minimum":0,"type":"number"},` +
+		`"tags":{"items":{"maxLength":10,"type":"string"},"type":"array"},` +
+		`"username":{"pattern":"^[a-z]+$","type":"string"}},` +
+		`"required":["email","address"],"type":"object"}`
+	if string(data) != expected {
+		t.Fatalf("expected %s but got %s instead", expected, data)
+	}
+}
+
+func TestSchemaJSONSchema(t *testing.T) {
+	data, err := json.Marshal(userSchema.JSONSchema())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"$schema":"https://json-schema.org/draft/2020-12/schema","properties":{` +
+		`"age":{"maximum":65,"minimum":17,"type":"integer"},` +
+		`"email":{"format":"email","minLength":1,"type":"string"},` +
+		`"nickname":{"maxLength":8,"minLength":2,"type":"string"},` +
+		`"tags":{"items":{"minLength":1,"type":"string"},"type":"array"}},` +
+		`"required":["email"],"type":"object"}`
+	if string(data) != expected {
+		t.Fatalf("expected %s but got %s instead", expected, data)
+	}
+}
+
+func TestJSONSchemaDecimals(t *testing.T) {
+	type payment struct {
+		Amount string   `json:"amount" gomal:"positive,multipleof=0.05,lessthanorequal=100.00"`
+		Total  *big.Int `json:"total" gomal:"unwrap,lessthanorequal=1000000000000000000000"`
+	}
+
+	data, err := json.Marshal(gomal.StructJSONSchema(payment{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"$schema":"https://json-schema.org/draft/2020-12/schema","properties":{` +
+		`"amount":{"type":"string"},` +
+		`"total":{"maximum":1000000000000000000000,"type":"integer"}},"type":"object"}`
+	if string(data) != expected {
+		t.Fatalf("expected %s but got %s instead", expected, data)
+	}
+
+	schema := gomal.Schema[user]().Field("fee", func(u user) any { return nil }, gomal.Rules.MultipleOf("+.50"))
+	data, err = json.Marshal(schema.JSONSchema()["properties"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `{"fee":{"multipleOf":0.50}}`; string(data) != expected {
+		t.Fatalf("expected %s but got %s instead", expected, data)
+	}
+}
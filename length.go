@@ -0,0 +1,55 @@
+package gomal
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+const zeroWidthJoiner = '\u200d'
+
+// stringLength measures s in the unit of the option.
+func stringLength(s string, option []ValidatorOption) int {
+	if len(option) > 0 && option[0].Unit == Graphemes {
+		return graphemeCount(s)
+	}
+	return utf8.RuneCountInString(s)
+}
+
+// graphemeCount counts the grapheme clusters of s. It follows the common cases
+// of Unicode text segmentation: CR LF, combining marks, variation selectors,
+// emoji modifiers, zero width joiner sequences and regional indicator pairs
+// (flags) extend the preceding character.
+func graphemeCount(s string) int {
+	count := 0
+	previous := rune(-1)
+	regionalIndicators := 0
+	for _, r := range s {
+		extends := false
+		switch {
+		case previous == '\r' && r == '\n':
+			extends = true
+		case unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc, unicode.Variation_Selector):
+			extends = previous != -1
+		case r == zeroWidthJoiner:
+			extends = previous != -1
+		case r >= 0x1f3fb && r <= 0x1f3ff:
+			// Emoji modifiers (skin tones).
+			extends = previous != -1
+		case previous == zeroWidthJoiner:
+			extends = true
+		case unicode.Is(unicode.Regional_Indicator, r):
+			extends = regionalIndicators%2 == 1
+		}
+
+		if unicode.Is(unicode.Regional_Indicator, r) {
+			regionalIndicators++
+		} else {
+			regionalIndicators = 0
+		}
+		if !extends {
+			count++
+		}
+		previous = r
+	}
+	return count
+}
@@ -0,0 +1,57 @@
+package gomal_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ItsMalma/gomal"
+)
+
+func TestLengthUnit(t *testing.T) {
+	graphemes := gomal.ValidatorOption{Unit: gomal.Graphemes}
+	tests := []struct {
+		name      string
+		validator gomal.Validator
+		results   []gomal.ValidationResult
+	}{
+		{
+			name:      "runes of accented name",
+			validator: gomal.If("name", "José").MaxLength(4),
+			results:   []gomal.ValidationResult{},
+		},
+		{
+			name:      "runes of japanese name",
+			validator: gomal.If("name", "山田太郎").Length(1, 20),
+			results:   []gomal.ValidationResult{},
+		},
+		{
+			name:      "runes of combining accent",
+			validator: gomal.If("name", "Jose\u0301").MaxLength(4),
+			results:   []gomal.ValidationResult{{Name: "name", Messages: []string{"The length of name must be 4 characters or fewer. You entered 5 characters."}}},
+		},
+		{
+			name:      "graphemes of combining accent",
+			validator: gomal.If("name", "Jose\u0301").MaxLength(4, graphemes),
+			results:   []gomal.ValidationResult{},
+		},
+		{
+			name:      "graphemes of emoji",
+			validator: gomal.If("reaction", "👍🏽🇮🇩👨‍👩‍👧").MinLength(4, graphemes),
+			results:   []gomal.ValidationResult{{Name: "reaction", Messages: []string{"The length of reaction must be at least 4 characters. You entered 3 characters."}}},
+		},
+		{
+			name:      "bytes",
+			validator: gomal.If("name", "山田").ByteLength(1, 4),
+			results:   []gomal.ValidationResult{{Name: "name", Messages: []string{"name must be between 1 and 4 bytes. You entered 6 bytes."}}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			results := gomal.Validate(test.validator)
+			if !reflect.DeepEqual(results, test.results) {
+				tt.Fatalf("expected %#v but got %#v instead", test.results, results)
+			}
+		})
+	}
+}
@@ -0,0 +1,261 @@
+package gomal
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"regexp"
+	"strconv"
+)
+
+// checkNumber records a violation of rule when the value is a number for which
+// failed holds, given the result of comparing it with another, or NaN.
+func (validator Validator) checkNumber(rule string, params map[string]any, another any, failed func(result int) bool, option []ValidatorOption) Validator {
+	if validator.stop {
+		return validator
+	}
+
+	if result, ok := compareNumber(validator.reflectValue, another); ok && (failed(result) || isNaN(validator.reflectValue)) {
+		validator.addViolation(rule, params, "", option)
+	}
+	return validator
+}
+
+// Positive checks that a number is greater than zero.
+func (validator Validator) Positive(option ...ValidatorOption) Validator {
+	return validator.checkNumber("positive", nil, 0, func(result int) bool { return result <= 0 }, option)
+}
+
+// Negative checks that a number is less than zero.
+func (validator Validator) Negative(option ...ValidatorOption) Validator {
+	return validator.checkNumber("negative", nil, 0, func(result int) bool { return result >= 0 }, option)
+}
+
+// NonZero checks that a number is not zero.
+func (validator Validator) NonZero(option ...ValidatorOption) Validator {
+	return validator.checkNumber("nonzero", nil, 0, func(result int) bool { return result == 0 }, option)
+}
+
+// Finite checks that a float or *big.Float is neither NaN nor an infinity.
+// Other numbers are always finite.
+func (validator Validator) Finite(option ...ValidatorOption) Validator {
+	if validator.stop {
+		return validator
+	}
+
+	if isNumeric(validator.reflectValue) {
+		if rat, _ := toRat(validator.reflectValue); rat == nil {
+			validator.addViolation("finite", nil, "", option)
+		}
+	}
+	return validator
+}
+
+// MultipleOf checks that a number is an exact multiple of step, e.g. 0.05 for
+// amounts rounded to five cents. Floats are compared by their shortest decimal
+// representation, so 0.3 is a multiple of 0.1.
+func (validator Validator) MultipleOf(step any, option ...ValidatorOption) Validator {
+	if validator.stop {
+		return validator
+	}
+
+	stepRat, _ := toRat(reflect.ValueOf(step))
+	if stepRat == nil || stepRat.Sign() == 0 {
+		panic(fmt.Sprintf("gomal: %v (%T) is not a non-zero finite number", step, step))
+	}
+	if isNumeric(validator.reflectValue) {
+		rat, _ := toRat(validator.reflectValue)
+		if rat == nil || !rat.Quo(rat, stepRat).IsInt() {
+			validator.addViolation("multipleof", map[string]any{"step": step}, "", option)
+		}
+	}
+	return validator
+}
+
+// Precision checks that a number has at most scale digits after the decimal
+// point once trailing zeros are dropped, so "12.50" passes Precision(2) and
+// Precision(1). It panics when scale is negative.
+func (validator Validator) Precision(scale int, option ...ValidatorOption) Validator {
+	if validator.stop {
+		return validator
+	}
+
+	if scale < 0 {
+		panic(fmt.Sprintf("gomal: precision scale %v is negative", scale))
+	}
+	if isNumeric(validator.reflectValue) {
+		rat, _ := toRat(validator.reflectValue)
+		if rat == nil || !rat.Mul(rat, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(scale)), nil))).IsInt() {
+			validator.addViolation("precision", map[string]any{"scale": scale}, "", option)
+		}
+	}
+	return validator
+}
+
+var (
+	bigIntType   = reflect.TypeOf(big.Int{})
+	bigRatType   = reflect.TypeOf(big.Rat{})
+	bigFloatType = reflect.TypeOf(big.Float{})
+
+	decimalRegexp = regexp.MustCompile(`^[+-]?([0-9]+(\.[0-9]*)?|\.[0-9]+)$`)
+)
+
+// compareNumber compares the numerical value with another number, returning
+// -1, 0 or +1. Numbers are ints, uints, floats, *big.Int, *big.Rat, *big.Float
+// and strings holding a decimal such as "-12.50"; mixing them compares the
+// exact values. It reports false when value is not a number, and panics when
+// another is not one. NaN equals everything, see isNaN.
+func compareNumber(value reflect.Value, another any) (int, bool) {
+	anotherValue := reflect.ValueOf(another)
+	if !isNumber(value.Kind()) || !isNumber(anotherValue.Kind()) {
+		if !isNumeric(value) {
+			return 0, false
+		}
+		if !isNumeric(anotherValue) {
+			panic(fmt.Sprintf("gomal: %v (%T) is not a number", another, another))
+		}
+		valueRat, valueInf := toRat(value)
+		anotherRat, anotherInf := toRat(anotherValue)
+		if valueInf != 0 || anotherInf != 0 {
+			return compare(valueInf, anotherInf), true
+		}
+		if valueRat == nil || anotherRat == nil {
+			return 0, true
+		}
+		return valueRat.Cmp(anotherRat), true
+	}
+
+	switch {
+	case isFloat(value.Kind()) || isFloat(anotherValue.Kind()):
+		return compare(toFloat64(value), toFloat64(anotherValue)), true
+	case isUint(value.Kind()) && isUint(anotherValue.Kind()):
+		return compare(value.Uint(), anotherValue.Uint()), true
+	case isUint(value.Kind()):
+		if anotherValue.Int() < 0 {
+			return 1, true
+		}
+		return compare(value.Uint(), uint64(anotherValue.Int())), true
+	case isUint(anotherValue.Kind()):
+		if value.Int() < 0 {
+			return -1, true
+		}
+		return compare(uint64(value.Int()), anotherValue.Uint()), true
+	}
+	return compare(value.Int(), anotherValue.Int()), true
+}
+
+func compare[T Ordered](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func toFloat64(value reflect.Value) float64 {
+	switch {
+	case isUint(value.Kind()):
+		return float64(value.Uint())
+	case isFloat(value.Kind()):
+		return value.Float()
+	}
+	return float64(value.Int())
+}
+
+// isNumeric reports whether value is a number accepted by compareNumber.
+func isNumeric(value reflect.Value) bool {
+	if !value.IsValid() {
+		return false
+	}
+	if isNumber(value.Kind()) {
+		return true
+	}
+	switch value.Kind() {
+	case reflect.String:
+		return decimalRegexp.MatchString(value.String())
+	case reflect.Pointer:
+		if value.IsNil() {
+			return false
+		}
+		value = value.Elem()
+	}
+	return value.Type() == bigIntType || value.Type() == bigRatType || value.Type() == bigFloatType
+}
+
+// toRat converts a numeric value to its exact rational value. Floats are
+// converted from their shortest decimal representation, so 0.1 is 1/10. An
+// infinity is reported by its sign with a nil rational, NaN by a nil rational
+// and a zero sign.
+func toRat(value reflect.Value) (*big.Rat, int) {
+	switch {
+	case isInt(value.Kind()):
+		return new(big.Rat).SetInt64(value.Int()), 0
+	case isUint(value.Kind()):
+		return new(big.Rat).SetInt(new(big.Int).SetUint64(value.Uint())), 0
+	case isFloat(value.Kind()):
+		float := value.Float()
+		if math.IsInf(float, 0) {
+			return nil, int(math.Copysign(1, float))
+		}
+		if math.IsNaN(float) {
+			return nil, 0
+		}
+		rat, _ := new(big.Rat).SetString(strconv.FormatFloat(float, 'g', -1, 64))
+		return rat, 0
+	case value.Kind() == reflect.String:
+		rat, _ := new(big.Rat).SetString(value.String())
+		return rat, 0
+	}
+
+	if value.Kind() != reflect.Pointer {
+		pointer := reflect.New(value.Type())
+		pointer.Elem().Set(value)
+		value = pointer
+	}
+	switch number := value.Interface().(type) {
+	case *big.Int:
+		return new(big.Rat).SetInt(number), 0
+	case *big.Rat:
+		return new(big.Rat).Set(number), 0
+	case *big.Float:
+		if number.IsInf() {
+			return nil, number.Sign()
+		}
+		rat, _ := number.Rat(nil)
+		return rat, 0
+	}
+	return nil, 0
+}
+
+// isNaN reports whether value is a floating-point NaN, which the numerical
+// rules reject since it satisfies no bound.
+func isNaN(value reflect.Value) bool {
+	return isFloat(value.Kind()) && math.IsNaN(value.Float())
+}
+
+func isNumber(kind reflect.Kind) bool {
+	return isInt(kind) || isUint(kind) || isFloat(kind)
+}
+
+func isInt(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+	return false
+}
+
+func isUint(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return false
+}
+
+func isFloat(kind reflect.Kind) bool {
+	return kind == reflect.Float32 || kind == reflect.Float64
+}
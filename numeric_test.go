@@ -0,0 +1,156 @@
+package gomal_test
+
+import (
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ItsMalma/gomal"
+)
+
+func TestNumericRules(t *testing.T) {
+	tests := []struct {
+		name      string
+		validator gomal.Validator
+		results   []gomal.ValidationResult
+	}{
+		{
+			name:      "big int limit",
+			validator: gomal.If("amount", new(big.Int).Exp(big.NewInt(10), big.NewInt(30), nil)).LessThanOrEqual(1000),
+			results:   []gomal.ValidationResult{{Name: "amount", Messages: []string{"amount must be less than or equal to 1000."}}},
+		},
+		{
+			name:      "big rat between decimals",
+			validator: gomal.If("rate", big.NewRat(1, 3)).Between("0.25", "0.5"),
+			results:   []gomal.ValidationResult{},
+		},
+		{
+			name:      "decimal string",
+			validator: gomal.If("price", "12.50").GreaterThan(12.5),
+			results:   []gomal.ValidationResult{{Name: "price", Messages: []string{"price must be greater than 12.5."}}},
+		},
+		{
+			name:      "NaN fails between",
+			validator: gomal.If("score", math.NaN()).Between(0, 10),
+			results:   []gomal.ValidationResult{{Name: "score", Messages: []string{"score must be between 0 and 10."}}},
+		},
+		{
+			name:      "finite",
+			validator: gomal.If("score", math.Inf(1)).Finite(),
+			results:   []gomal.ValidationResult{{Name: "score", Messages: []string{"score must be a finite number."}}},
+		},
+		{
+			name:      "multiple of float step",
+			validator: gomal.If("amount", 0.3).MultipleOf(0.1),
+			results:   []gomal.ValidationResult{},
+		},
+		{
+			name:      "multiple of decimal step",
+			validator: gomal.If("amount", "10.07").MultipleOf("0.05"),
+			results:   []gomal.ValidationResult{{Name: "amount", Messages: []string{"amount must be a multiple of 0.05."}}},
+		},
+		{
+			name:      "nil is skipped",
+			validator: gomal.If("amount", nil).LessThan(5).Finite().Precision(2).MultipleOf(5),
+			results:   []gomal.ValidationResult{},
+		},
+		{
+			name:      "precision",
+			validator: gomal.If("amount", "12.50").Precision(1),
+			results:   []gomal.ValidationResult{},
+		},
+		{
+			name:      "precision of rat",
+			validator: gomal.If("amount", big.NewRat(1, 3)).Precision(2),
+			results:   []gomal.ValidationResult{{Name: "amount", Messages: []string{"amount must have at most 2 decimal places."}}},
+		},
+		{
+			name:      "positive",
+			validator: gomal.If("amount", big.NewInt(0)).Positive(),
+			results:   []gomal.ValidationResult{{Name: "amount", Messages: []string{"amount must be positive."}}},
+		},
+		{
+			name:      "negative",
+			validator: gomal.If("amount", "-0.01").Negative(),
+			results:   []gomal.ValidationResult{},
+		},
+		{
+			name:      "non zero",
+			validator: gomal.If("amount", uint(0)).NonZero(),
+			results:   []gomal.ValidationResult{{Name: "amount", Messages: []string{"amount must not be zero."}}},
+		},
+		{
+			name:      "not a decimal",
+			validator: gomal.If("amount", "abc").Positive().LessThan(10),
+			results:   []gomal.ValidationResult{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			results := gomal.Validate(test.validator)
+			if !reflect.DeepEqual(results, test.results) {
+				tt.Fatalf("expected %#v but got %#v instead", test.results, results)
+			}
+		})
+	}
+}
+
+func TestNumericTags(t *testing.T) {
+	type payment struct {
+		Amount   string   `json:"amount" gomal:"positive,precision=2,lessthanorequal=1000.00"`
+		Fee      *big.Rat `json:"fee" gomal:"unwrap,multipleof=0.05"`
+		Discount float64  `json:"discount" gomal:"finite,between=0|1"`
+	}
+
+	expected := []gomal.ValidationResult{
+		{Name: "amount", Messages: []string{"amount must have at most 2 decimal places.", "amount must be less than or equal to 1000.00."}},
+		{Name: "fee", Messages: []string{"fee must be a multiple of 0.05."}},
+		{Name: "discount", Messages: []string{"discount must be a finite number.", "discount must be between 0 and 1."}},
+	}
+	results := gomal.ValidateStruct(payment{Amount: "1000.005", Fee: big.NewRat(1, 100), Discount: math.NaN()})
+	if !reflect.DeepEqual(results, expected) {
+		t.Fatalf("expected %#v but got %#v instead", expected, results)
+	}
+}
+
+func TestNumericTagsInvalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+	}{
+		{
+			name: "zero step",
+			value: struct {
+				Amount int `gomal:"multipleof=0"`
+			}{},
+		},
+		{
+			name: "zero decimal step",
+			value: struct {
+				Amount string `gomal:"multipleof=0.00"`
+			}{},
+		},
+		{
+			name: "infinite step",
+			value: struct {
+				Amount float64 `gomal:"multipleof=+Inf"`
+			}{},
+		},
+		{
+			name: "negative scale",
+			value: struct {
+				Amount string `gomal:"precision=-1"`
+			}{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			if err := gomal.CheckTags(test.value); err == nil {
+				tt.Fatalf("expected an error but got nil instead")
+			}
+		})
+	}
+}
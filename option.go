@@ -1,5 +1,23 @@
 package gomal
 
+import "time"
+
 type ValidatorOption struct {
 	ErrorMessage string
+	// Unit selects what Length, MinLength and MaxLength count.
+	Unit LengthUnit
+	// Now replaces time.Now in the rules relative to the current time, such as
+	// NotInPast and MinAge, e.g. to freeze the clock in tests.
+	Now func() time.Time
 }
+
+// LengthUnit is the unit in which the length of a string is measured.
+type LengthUnit int
+
+const (
+	// Runes counts Unicode code points, so "José" is 4 characters long.
+	Runes LengthUnit = iota
+	// Graphemes counts user-perceived characters, so an accent written as a
+	// combining mark, an emoji with a skin tone or a flag counts as one.
+	Graphemes
+)
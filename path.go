@@ -0,0 +1,96 @@
+package gomal
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+func fieldPath(parent, field string) string {
+	if parent == "" {
+		return field
+	}
+	return parent + "." + field
+}
+
+func indexPath(parent string, index int) string {
+	return parent + "[" + strconv.Itoa(index) + "]"
+}
+
+func keyPath(parent string, key reflect.Value) string {
+	if key.Kind() == reflect.String {
+		return parent + "[" + strconv.Quote(key.String()) + "]"
+	}
+	return fmt.Sprintf("%v[%v]", parent, key.Interface())
+}
+
+// isNil reports whether value is nil, either untyped or as a nil pointer, map,
+// slice, channel, function or interface.
+func isNil(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice:
+		return value.IsNil()
+	}
+	return false
+}
+
+// isEmpty reports whether value is nil, has no elements, is false or zero, or
+// is a string of whitespace only.
+func isEmpty(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Array, reflect.Chan, reflect.Map, reflect.Slice:
+		return value.Len() < 1
+	case reflect.Pointer:
+		return value.IsNil() || (value.Elem().Kind() == reflect.Array && value.Elem().Len() < 1)
+	case reflect.Bool:
+		return !value.Bool()
+	case reflect.Complex64, reflect.Complex128:
+		return value.Complex() == 0
+	case reflect.Float32, reflect.Float64:
+		return value.Float() == 0
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return value.Uint() == 0
+	case reflect.String:
+		return strings.TrimFunc(value.String(), unicode.IsSpace) == ""
+	}
+	return false
+}
+
+// indirect follows pointers and interfaces, returning the zero Value when it
+// reaches nil.
+func indirect(value reflect.Value) reflect.Value {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return reflect.Value{}
+		}
+		value = value.Elem()
+	}
+	return value
+}
+
+func sortedMapKeys(value reflect.Value) []reflect.Value {
+	keys := value.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		switch keys[i].Kind() {
+		case reflect.String:
+			return keys[i].String() < keys[j].String()
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return keys[i].Int() < keys[j].Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			return keys[i].Uint() < keys[j].Uint()
+		case reflect.Float32, reflect.Float64:
+			return keys[i].Float() < keys[j].Float()
+		}
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+	return keys
+}
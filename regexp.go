@@ -0,0 +1,73 @@
+package gomal
+
+import (
+	"container/list"
+	"fmt"
+	"regexp"
+	"sync"
+)
+
+// regexpCacheSize is the number of string patterns kept compiled by
+// compileRegexp.
+const regexpCacheSize = 256
+
+type cachedRegexp struct {
+	pattern string
+	regexp  *regexp.Regexp
+}
+
+// regexpCache holds the most recently used patterns that compiled, so patterns
+// taken from loaded schemas or input cannot grow it without bound.
+var regexpCache = struct {
+	sync.Mutex
+	entries map[string]*list.Element
+	recent  *list.List
+}{
+	entries: map[string]*list.Element{},
+	recent:  list.New(),
+}
+
+// compileRegexp compiles pattern, a string or a *regexp.Regexp, reusing the
+// result for recently used strings.
+func compileRegexp(pattern any) (*regexp.Regexp, error) {
+	switch pattern := pattern.(type) {
+	case *regexp.Regexp:
+		return pattern, nil
+	case string:
+		regexpCache.Lock()
+		if element, ok := regexpCache.entries[pattern]; ok {
+			regexpCache.recent.MoveToFront(element)
+			regexpCache.Unlock()
+			return element.Value.(cachedRegexp).regexp, nil
+		}
+		regexpCache.Unlock()
+
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("gomal: invalid pattern %q: %w", pattern, err)
+		}
+
+		regexpCache.Lock()
+		defer regexpCache.Unlock()
+		if _, ok := regexpCache.entries[pattern]; !ok {
+			regexpCache.entries[pattern] = regexpCache.recent.PushFront(cachedRegexp{pattern: pattern, regexp: compiled})
+			if regexpCache.recent.Len() > regexpCacheSize {
+				oldest := regexpCache.recent.Back()
+				regexpCache.recent.Remove(oldest)
+				delete(regexpCache.entries, oldest.Value.(cachedRegexp).pattern)
+			}
+		}
+		return compiled, nil
+	}
+	return nil, fmt.Errorf("gomal: pattern must be a string or a *regexp.Regexp but got %T", pattern)
+}
+
+// mustCompileRegexp is compileRegexp panicking on error, for rules declared
+// ahead of validation.
+func mustCompileRegexp(pattern any) *regexp.Regexp {
+	compiled, err := compileRegexp(pattern)
+	if err != nil {
+		panic(err)
+	}
+	return compiled
+}
@@ -0,0 +1,100 @@
+package gomal_test
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ItsMalma/gomal"
+)
+
+func TestRegExp(t *testing.T) {
+	tests := []struct {
+		name      string
+		validator gomal.Validator
+		results   []gomal.ValidationResult
+	}{
+		{
+			name:      "string pattern",
+			validator: gomal.If("code", "AB-12").RegExp(`^[A-Z]+-\d+$`),
+			results:   []gomal.ValidationResult{},
+		},
+		{
+			name:      "compiled pattern",
+			validator: gomal.If("code", "ab-12").RegExp(regexp.MustCompile(`^[A-Z]+-\d+$`)),
+			results:   []gomal.ValidationResult{{Name: "code", Messages: []string{"code is not in the correct format"}}},
+		},
+		{
+			name:      "invalid pattern",
+			validator: gomal.If("code", "AB-12").RegExp(`^[A-Z`),
+			results:   []gomal.ValidationResult{{Name: "code", Messages: []string{"code is not in the correct format"}}},
+		},
+		{
+			name:      "invalid pattern on a number",
+			validator: gomal.If("code", 5).RegExp(`^[A-Z`),
+			results:   []gomal.ValidationResult{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			results := gomal.Validate(test.validator)
+			if !reflect.DeepEqual(results, test.results) {
+				tt.Fatalf("expected %#v but got %#v instead", test.results, results)
+			}
+		})
+	}
+}
+
+func TestRegExpConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if results := gomal.Validate(gomal.If("code", "AB-12").RegExp(`^[A-Z]+-\d+$`)); len(results) > 0 {
+				t.Errorf("expected no results but got %#v instead", results)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestRegExpManyPatterns(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		pattern := fmt.Sprintf(`^[A-Z]{%d}$`, i%300+1)
+		if results := gomal.Validate(gomal.If("code", "A").RegExp(pattern)); (len(results) == 0) != (i%300 == 0) {
+			t.Fatalf("unexpected results %#v for %v", results, pattern)
+		}
+	}
+}
+
+func TestRulesRegExpInvalid(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected panic for invalid pattern")
+		}
+	}()
+
+	gomal.Rules.RegExp(`^[A-Z`)
+}
+
+func TestCheckTags(t *testing.T) {
+	type item struct {
+		Code string `gomal:"regexp=^[A-Z"`
+	}
+	type request struct {
+		Items []item `json:"items"`
+	}
+
+	if err := gomal.CheckTags(orderRequest{}); err != nil {
+		t.Fatalf("expected no error but got %v instead", err)
+	}
+	err := gomal.CheckTags(&request{})
+	if err == nil || !strings.Contains(err.Error(), "invalid pattern") {
+		t.Fatalf("expected invalid pattern error but got %v instead", err)
+	}
+}
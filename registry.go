@@ -0,0 +1,115 @@
+package gomal
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// RuleFactory builds a registered rule from the parameter written after "=" in
+// a tag, which is empty when there is none.
+type RuleFactory func(param string) (Rule, error)
+
+var (
+	registryMutex sync.RWMutex
+	registry      = map[string]RuleFactory{}
+)
+
+// RegisterRule makes a custom rule available by name in struct tags and
+// ParseRules, next to the built-in ones, e.g.
+//
+//	gomal.RegisterRule("sku", func(param string) (gomal.Rule, error) {
+//		return gomal.NewRule("sku", nil, isSKU), nil
+//	})
+//
+//	SKU string `json:"sku" gomal:"notempty,sku"`
+//
+// It is safe for concurrent use, but rules are typically registered from init
+// functions. It panics if name is taken, by a built-in or registered rule, or
+// if factory is nil.
+func RegisterRule(name string, factory RuleFactory) {
+	if factory == nil {
+		panic("gomal: RegisterRule factory is nil")
+	}
+	if _, ok := tagRules[name]; ok || name == "dive" {
+		panic(fmt.Sprintf("gomal: RegisterRule called for the built-in rule %q", name))
+	}
+
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+	if _, ok := registry[name]; ok {
+		panic(fmt.Sprintf("gomal: RegisterRule called twice for %q", name))
+	}
+	registry[name] = factory
+}
+
+func registeredRule(name string) (RuleFactory, bool) {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+	factory, ok := registry[name]
+	return factory, ok
+}
+
+// NewRule returns a Rule recording a violation of code with params when check
+// reports false for the value. Its message is the option's ErrorMessage or the
+// translator's template for code, so add one to the Catalog, or a generic "is
+// not a valid value". Rules whose params depend on the value, such as the
+// actual length, implement Rule and record their violations with Fail.
+func NewRule(code string, params map[string]any, check func(value any) bool, option ...ValidatorOption) Rule {
+	return rule{
+		code:   code,
+		params: params,
+		apply: func(validator Validator) Validator {
+			if validator.stop {
+				return validator
+			}
+			if !check(validator.value) {
+				validator.addViolation(code, params, "", option)
+			}
+			return validator
+		},
+	}
+}
+
+// Fail records a violation of code with params, for rules written outside
+// gomal that implement Rule themselves, e.g. to report the actual value:
+//
+//	func (rule maxWords) Apply(validator gomal.Validator) gomal.Validator {
+//		text, _ := validator.Value().(string)
+//		if words := len(strings.Fields(text)); words > rule.max {
+//			return validator.Fail("maxwords", map[string]any{"max": rule.max, "actual": words}, rule.option)
+//		}
+//		return validator
+//	}
+//
+// The message is the translator's template for code, with params filled in, or
+// the option's ErrorMessage. Nothing is recorded when the rules of the
+// validator are skipped, see When.
+func (validator Validator) Fail(code string, params map[string]any, option ...ValidatorOption) Validator {
+	if validator.stop {
+		return validator
+	}
+
+	validator.addViolation(code, params, "", option)
+	return validator
+}
+
+// ParseRules parses rules written like a gomal struct tag, such as
+// "notempty,sku,length=3|64", for values of type T.
+func ParseRules[T any](definition string) ([]Rule, error) {
+	rules, _, err := parseTag(definition, reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		return nil, fmt.Errorf("gomal: invalid rules %q: %w", definition, err)
+	}
+	return rules, nil
+}
+
+// Use applies rules in order, such as ones built with NewRule, parsed with
+// ParseRules or taken from Rules.
+func (validator Validator) Use(rules ...Rule) Validator {
+	if validator.stop {
+		return validator
+	}
+
+	return applyRules(validator, rules)
+}
@@ -0,0 +1,177 @@
+package gomal_test
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ItsMalma/gomal"
+)
+
+var skuRegexp = regexp.MustCompile(`^[A-Z]{2}-[0-9]{4}$`)
+
+func init() {
+	gomal.RegisterRule("sku", func(param string) (gomal.Rule, error) {
+		return gomal.NewRule("sku", nil, func(value any) bool {
+			sku, ok := value.(string)
+			return ok && skuRegexp.MatchString(sku)
+		}), nil
+	})
+	gomal.RegisterRule("tenant_slug", func(param string) (gomal.Rule, error) {
+		return gomal.NewRule("tenant_slug", map[string]any{"prefix": param}, func(value any) bool {
+			slug, ok := value.(string)
+			return ok && strings.HasPrefix(slug, param+"-")
+		}), nil
+	})
+}
+
+func TestRegisterRule(t *testing.T) {
+	type product struct {
+		SKU    string `json:"sku" gomal:"notempty,sku"`
+		Tenant string `json:"tenant" gomal:"tenant_slug=acme"`
+	}
+
+	catalog := gomal.English()
+	catalog.Messages["tenant_slug"] = "{field} must start with {prefix}-."
+
+	expected := []gomal.ValidationResult{
+		{Name: "sku", Messages: []string{"sku is not a valid value."}},
+		{Name: "tenant", Messages: []string{"tenant must start with acme-."}},
+	}
+	results := gomal.ValidateStructWith(catalog, product{SKU: "ab-12", Tenant: "globex-shop"})
+	if !reflect.DeepEqual(results, expected) {
+		t.Fatalf("expected %#v but got %#v instead", expected, results)
+	}
+}
+
+func TestRegisterRuleTwice(t *testing.T) {
+	tests := []struct {
+		name string
+		rule string
+	}{
+		{name: "custom", rule: "sku"},
+		{name: "built-in", rule: "email"},
+		{name: "dive", rule: "dive"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			defer func() {
+				if recover() == nil {
+					tt.Fatalf("expected panic for the taken name %q", test.rule)
+				}
+			}()
+
+			gomal.RegisterRule(test.rule, func(param string) (gomal.Rule, error) { return gomal.Rules.Email(), nil })
+		})
+	}
+}
+
+func TestParseRules(t *testing.T) {
+	rules, err := gomal.ParseRules[[]string]("minitems=1,dive,notempty,sku")
+	if err != nil {
+		t.Fatalf("expected no error but got %v instead", err)
+	}
+
+	expected := []gomal.ValidationResult{{Name: "skus[1]", Messages: []string{"skus[1] is not a valid value."}}}
+	results := gomal.Validate(gomal.If("skus", []string{"AB-1234", "x"}).Use(rules...))
+	if !reflect.DeepEqual(results, expected) {
+		t.Fatalf("expected %#v but got %#v instead", expected, results)
+	}
+
+	if _, err := gomal.ParseRules[string]("notempty,unknown"); err == nil {
+		t.Fatalf("expected error for unknown rule")
+	}
+}
+
+func TestParseRulesConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := gomal.ParseRules[string]("sku"); err != nil {
+				t.Errorf("expected no error but got %v instead", err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+type maxWords struct {
+	max    int
+	option []gomal.ValidatorOption
+}
+
+func (rule maxWords) Code() string {
+	return "maxwords"
+}
+
+func (rule maxWords) Params() map[string]any {
+	return map[string]any{"max": rule.max}
+}
+
+func (rule maxWords) Apply(validator gomal.Validator) gomal.Validator {
+	text, _ := validator.Value().(string)
+	if words := len(strings.Fields(text)); words > rule.max {
+		return validator.Fail("maxwords", map[string]any{"max": rule.max, "actual": words}, rule.option...)
+	}
+	return validator
+}
+
+func TestFail(t *testing.T) {
+	catalog := gomal.English()
+	catalog.Messages["maxwords"] = "{field} must have at most {max} words. You entered {actual} words."
+
+	tests := []struct {
+		name    string
+		rule    maxWords
+		value   string
+		results []gomal.ValidationResult
+	}{
+		{
+			name:    "success",
+			rule:    maxWords{max: 3},
+			value:   "a short bio",
+			results: []gomal.ValidationResult{},
+		},
+		{
+			name:    "failed",
+			rule:    maxWords{max: 2},
+			value:   "a short bio",
+			results: []gomal.ValidationResult{{Name: "bio", Messages: []string{"bio must have at most 2 words. You entered 3 words."}}},
+		},
+		{
+			name:    "custom message",
+			rule:    maxWords{max: 2, option: []gomal.ValidatorOption{{ErrorMessage: "Keep it short"}}},
+			value:   "a short bio",
+			results: []gomal.ValidationResult{{Name: "bio", Messages: []string{"Keep it short"}}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			results := gomal.ValidateWith(catalog, gomal.If("bio", test.value).Use(test.rule))
+			if !reflect.DeepEqual(results, test.results) {
+				tt.Fatalf("expected %#v but got %#v instead", test.results, results)
+			}
+		})
+	}
+
+	violations := gomal.Violations(gomal.If("bio", "a short bio").Use(maxWords{max: 2}))
+	expected := []gomal.Violation{{Field: "bio", Rule: "maxwords", Params: map[string]any{"max": 2, "actual": 3}, Value: "a short bio", Message: "bio is not a valid value."}}
+	if !reflect.DeepEqual(violations, expected) {
+		t.Fatalf("expected %#v but got %#v instead", expected, violations)
+	}
+
+	rule := gomal.NewRule("sku", nil, func(value any) bool { return false }, gomal.ValidatorOption{ErrorMessage: "Enter a SKU"})
+	if results := gomal.Validate(gomal.If("sku", "x").Use(rule)); !reflect.DeepEqual(results, []gomal.ValidationResult{{Name: "sku", Messages: []string{"Enter a SKU"}}}) {
+		t.Fatalf("expected the custom message but got %#v instead", results)
+	}
+
+	if results := gomal.Validate(gomal.If("bio", "a short bio").When(false).Fail("maxwords", nil)); len(results) != 0 {
+		t.Fatalf("expected no results but got %#v instead", results)
+	}
+}
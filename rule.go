@@ -0,0 +1,599 @@
+package gomal
+
+import (
+	"context"
+	"time"
+)
+
+// Rule is a validation step declared independently of the value it checks, so
+// it can be built once, reused and inspected. Every method of Validator that
+// checks a value has a Rule counterpart in Rules.
+type Rule interface {
+	// Code identifies the rule, as recorded in Violation.Rule.
+	Code() string
+	// Params returns the arguments the rule was declared with, keyed like the
+	// params of its violations.
+	Params() map[string]any
+	// Apply runs the rule against validator. Rules implemented outside gomal
+	// record their violations with Validator.Fail.
+	Apply(validator Validator) Validator
+}
+
+type rule struct {
+	code   string
+	params map[string]any
+	apply  func(validator Validator) Validator
+}
+
+func (rule rule) Code() string {
+	return rule.code
+}
+
+func (rule rule) Params() map[string]any {
+	return rule.params
+}
+
+func (rule rule) Apply(validator Validator) Validator {
+	return rule.apply(validator)
+}
+
+// BuiltinRules builds the Rule counterparts of the Validator methods. Use it
+// through Rules, e.g. gomal.Rules.Length(3, 64).
+type BuiltinRules struct{}
+
+var Rules BuiltinRules
+
+func (BuiltinRules) NotNil(option ...ValidatorOption) Rule {
+	return rule{code: "notnil", apply: func(validator Validator) Validator { return validator.NotNil(option...) }}
+}
+
+func (BuiltinRules) NotEmpty(option ...ValidatorOption) Rule {
+	return rule{code: "notempty", apply: func(validator Validator) Validator { return validator.NotEmpty(option...) }}
+}
+
+func (BuiltinRules) NotEqual(another any, option ...ValidatorOption) Rule {
+	return rule{
+		code:   "notequal",
+		params: map[string]any{"other": another},
+		apply:  func(validator Validator) Validator { return validator.NotEqual(another, option...) },
+	}
+}
+
+func (BuiltinRules) Equal(another any, option ...ValidatorOption) Rule {
+	return rule{
+		code:   "equal",
+		params: map[string]any{"other": another},
+		apply:  func(validator Validator) Validator { return validator.Equal(another, option...) },
+	}
+}
+
+// OneOf checks membership in values, or in the enum of the value's type when
+// there are none, see Validator.OneOf.
+func (BuiltinRules) OneOf(values ...any) Rule {
+	return oneOfRule(values, nil)
+}
+
+func (BuiltinRules) OneOfWith(option ValidatorOption, values ...any) Rule {
+	return oneOfRule(values, []ValidatorOption{option})
+}
+
+func oneOfRule(values []any, option []ValidatorOption) Rule {
+	var params map[string]any
+	if len(values) > 0 {
+		params = map[string]any{"values": values}
+	}
+	return rule{
+		code:   "oneof",
+		params: params,
+		apply:  func(validator Validator) Validator { return validator.oneOf(values, option) },
+	}
+}
+
+func (BuiltinRules) NotOneOf(values ...any) Rule {
+	return notOneOfRule(values, nil)
+}
+
+func (BuiltinRules) NotOneOfWith(option ValidatorOption, values ...any) Rule {
+	return notOneOfRule(values, []ValidatorOption{option})
+}
+
+func notOneOfRule(values []any, option []ValidatorOption) Rule {
+	return rule{
+		code:   "notoneof",
+		params: map[string]any{"values": values},
+		apply:  func(validator Validator) Validator { return validator.notOneOf(values, option) },
+	}
+}
+
+func (BuiltinRules) Length(min, max int, option ...ValidatorOption) Rule {
+	return rule{
+		code:   "length",
+		params: map[string]any{"min": min, "max": max},
+		apply:  func(validator Validator) Validator { return validator.Length(min, max, option...) },
+	}
+}
+
+func (BuiltinRules) MaxLength(max int, option ...ValidatorOption) Rule {
+	return rule{
+		code:   "maxlength",
+		params: map[string]any{"max": max},
+		apply:  func(validator Validator) Validator { return validator.MaxLength(max, option...) },
+	}
+}
+
+func (BuiltinRules) MinLength(min int, option ...ValidatorOption) Rule {
+	return rule{
+		code:   "minlength",
+		params: map[string]any{"min": min},
+		apply:  func(validator Validator) Validator { return validator.MinLength(min, option...) },
+	}
+}
+
+func (BuiltinRules) ByteLength(min, max int, option ...ValidatorOption) Rule {
+	return rule{
+		code:   "bytelength",
+		params: map[string]any{"min": min, "max": max},
+		apply:  func(validator Validator) Validator { return validator.ByteLength(min, max, option...) },
+	}
+}
+
+func (BuiltinRules) LessThan(another any, option ...ValidatorOption) Rule {
+	return rule{
+		code:   "lessthan",
+		params: map[string]any{"limit": another},
+		apply:  func(validator Validator) Validator { return validator.LessThan(another, option...) },
+	}
+}
+
+func (BuiltinRules) LessThanOrEqual(another any, option ...ValidatorOption) Rule {
+	return rule{
+		code:   "lessthanorequal",
+		params: map[string]any{"limit": another},
+		apply:  func(validator Validator) Validator { return validator.LessThanOrEqual(another, option...) },
+	}
+}
+
+func (BuiltinRules) GreaterThan(another any, option ...ValidatorOption) Rule {
+	return rule{
+		code:   "greaterthan",
+		params: map[string]any{"limit": another},
+		apply:  func(validator Validator) Validator { return validator.GreaterThan(another, option...) },
+	}
+}
+
+func (BuiltinRules) GreaterThanOrEqual(another any, option ...ValidatorOption) Rule {
+	return rule{
+		code:   "greaterthanorequal",
+		params: map[string]any{"limit": another},
+		apply:  func(validator Validator) Validator { return validator.GreaterThanOrEqual(another, option...) },
+	}
+}
+
+func (BuiltinRules) Positive(option ...ValidatorOption) Rule {
+	return rule{code: "positive", apply: func(validator Validator) Validator { return validator.Positive(option...) }}
+}
+
+func (BuiltinRules) Negative(option ...ValidatorOption) Rule {
+	return rule{code: "negative", apply: func(validator Validator) Validator { return validator.Negative(option...) }}
+}
+
+func (BuiltinRules) NonZero(option ...ValidatorOption) Rule {
+	return rule{code: "nonzero", apply: func(validator Validator) Validator { return validator.NonZero(option...) }}
+}
+
+func (BuiltinRules) Finite(option ...ValidatorOption) Rule {
+	return rule{code: "finite", apply: func(validator Validator) Validator { return validator.Finite(option...) }}
+}
+
+func (BuiltinRules) MultipleOf(step any, option ...ValidatorOption) Rule {
+	return rule{
+		code:   "multipleof",
+		params: map[string]any{"step": step},
+		apply:  func(validator Validator) Validator { return validator.MultipleOf(step, option...) },
+	}
+}
+
+func (BuiltinRules) Precision(scale int, option ...ValidatorOption) Rule {
+	return rule{
+		code:   "precision",
+		params: map[string]any{"scale": scale},
+		apply:  func(validator Validator) Validator { return validator.Precision(scale, option...) },
+	}
+}
+
+// RegExp is Validator.RegExp compiling pattern right away. Like
+// regexp.MustCompile, it panics when pattern is invalid, so a bad pattern in a
+// schema declared in a package variable fails at startup.
+func (BuiltinRules) RegExp(pattern any, option ...ValidatorOption) Rule {
+	compiled := mustCompileRegexp(pattern)
+	return rule{
+		code:   "regexp",
+		params: map[string]any{"pattern": compiled.String()},
+		apply:  func(validator Validator) Validator { return validator.RegExp(compiled, option...) },
+	}
+}
+
+func (BuiltinRules) Email(option ...ValidatorOption) Rule {
+	return rule{code: "email", apply: func(validator Validator) Validator { return validator.Email(option...) }}
+}
+
+func (BuiltinRules) StrictEmail(option ...ValidatorOption) Rule {
+	return rule{code: "strictemail", apply: func(validator Validator) Validator { return validator.StrictEmail(option...) }}
+}
+
+func (BuiltinRules) URL(schemes []string, option ...ValidatorOption) Rule {
+	var params map[string]any
+	if len(schemes) > 0 {
+		params = map[string]any{"schemes": stringsToAny(schemes)}
+	}
+	return rule{
+		code:   "url",
+		params: params,
+		apply:  func(validator Validator) Validator { return validator.URL(schemes, option...) },
+	}
+}
+
+func (BuiltinRules) UUID(version int, option ...ValidatorOption) Rule {
+	var params map[string]any
+	if version > 0 {
+		params = map[string]any{"version": version}
+	}
+	return rule{
+		code:   "uuid",
+		params: params,
+		apply:  func(validator Validator) Validator { return validator.UUID(version, option...) },
+	}
+}
+
+func (BuiltinRules) IP(option ...ValidatorOption) Rule {
+	return rule{code: "ip", apply: func(validator Validator) Validator { return validator.IP(option...) }}
+}
+
+func (BuiltinRules) IPv4(option ...ValidatorOption) Rule {
+	return rule{code: "ipv4", apply: func(validator Validator) Validator { return validator.IPv4(option...) }}
+}
+
+func (BuiltinRules) IPv6(option ...ValidatorOption) Rule {
+	return rule{code: "ipv6", apply: func(validator Validator) Validator { return validator.IPv6(option...) }}
+}
+
+func (BuiltinRules) CIDR(option ...ValidatorOption) Rule {
+	return rule{code: "cidr", apply: func(validator Validator) Validator { return validator.CIDR(option...) }}
+}
+
+func (BuiltinRules) Hostname(option ...ValidatorOption) Rule {
+	return rule{code: "hostname", apply: func(validator Validator) Validator { return validator.Hostname(option...) }}
+}
+
+func (BuiltinRules) FQDN(option ...ValidatorOption) Rule {
+	return rule{code: "fqdn", apply: func(validator Validator) Validator { return validator.FQDN(option...) }}
+}
+
+func (BuiltinRules) MACAddress(option ...ValidatorOption) Rule {
+	return rule{code: "macaddress", apply: func(validator Validator) Validator { return validator.MACAddress(option...) }}
+}
+
+func (BuiltinRules) E164Phone(option ...ValidatorOption) Rule {
+	return rule{code: "e164phone", apply: func(validator Validator) Validator { return validator.E164Phone(option...) }}
+}
+
+func (BuiltinRules) ISO8601Date(option ...ValidatorOption) Rule {
+	return rule{code: "iso8601date", apply: func(validator Validator) Validator { return validator.ISO8601Date(option...) }}
+}
+
+func (BuiltinRules) RFC3339(option ...ValidatorOption) Rule {
+	return rule{code: "rfc3339", apply: func(validator Validator) Validator { return validator.RFC3339(option...) }}
+}
+
+func (BuiltinRules) Base64(option ...ValidatorOption) Rule {
+	return rule{code: "base64", apply: func(validator Validator) Validator { return validator.Base64(option...) }}
+}
+
+func (BuiltinRules) Hex(option ...ValidatorOption) Rule {
+	return rule{code: "hex", apply: func(validator Validator) Validator { return validator.Hex(option...) }}
+}
+
+func (BuiltinRules) JSON(option ...ValidatorOption) Rule {
+	return rule{code: "json", apply: func(validator Validator) Validator { return validator.JSON(option...) }}
+}
+
+func (BuiltinRules) Semver(option ...ValidatorOption) Rule {
+	return rule{code: "semver", apply: func(validator Validator) Validator { return validator.Semver(option...) }}
+}
+
+func (BuiltinRules) Slug(option ...ValidatorOption) Rule {
+	return rule{code: "slug", apply: func(validator Validator) Validator { return validator.Slug(option...) }}
+}
+
+func (BuiltinRules) Empty(option ...ValidatorOption) Rule {
+	return rule{code: "empty", apply: func(validator Validator) Validator { return validator.Empty(option...) }}
+}
+
+func (BuiltinRules) Nil(option ...ValidatorOption) Rule {
+	return rule{code: "nil", apply: func(validator Validator) Validator { return validator.Nil(option...) }}
+}
+
+func (BuiltinRules) Between(min, max any, option ...ValidatorOption) Rule {
+	return rule{
+		code:   "between",
+		params: map[string]any{"min": min, "max": max},
+		apply:  func(validator Validator) Validator { return validator.Between(min, max, option...) },
+	}
+}
+
+func (BuiltinRules) Before(limit time.Time, option ...ValidatorOption) Rule {
+	return rule{
+		code:   "before",
+		params: map[string]any{"limit": limit},
+		apply:  func(validator Validator) Validator { return validator.Before(limit, option...) },
+	}
+}
+
+func (BuiltinRules) After(limit time.Time, option ...ValidatorOption) Rule {
+	return rule{
+		code:   "after",
+		params: map[string]any{"limit": limit},
+		apply:  func(validator Validator) Validator { return validator.After(limit, option...) },
+	}
+}
+
+func (BuiltinRules) BetweenTimes(min, max time.Time, option ...ValidatorOption) Rule {
+	return rule{
+		code:   "betweentimes",
+		params: map[string]any{"min": min, "max": max},
+		apply:  func(validator Validator) Validator { return validator.BetweenTimes(min, max, option...) },
+	}
+}
+
+func (BuiltinRules) Within(duration time.Duration, option ...ValidatorOption) Rule {
+	return rule{
+		code:   "within",
+		params: map[string]any{"duration": duration},
+		apply:  func(validator Validator) Validator { return validator.Within(duration, option...) },
+	}
+}
+
+func (BuiltinRules) NotInPast(option ...ValidatorOption) Rule {
+	return rule{code: "notinpast", apply: func(validator Validator) Validator { return validator.NotInPast(option...) }}
+}
+
+func (BuiltinRules) NotInFuture(option ...ValidatorOption) Rule {
+	return rule{code: "notinfuture", apply: func(validator Validator) Validator { return validator.NotInFuture(option...) }}
+}
+
+func (BuiltinRules) MinAge(years int, option ...ValidatorOption) Rule {
+	return rule{
+		code:   "minage",
+		params: map[string]any{"years": years},
+		apply:  func(validator Validator) Validator { return validator.MinAge(years, option...) },
+	}
+}
+
+func (BuiltinRules) Weekday(days []time.Weekday, option ...ValidatorOption) Rule {
+	allowed := make([]any, len(days))
+	for i, day := range days {
+		allowed[i] = day
+	}
+	return rule{
+		code:   "weekday",
+		params: map[string]any{"days": allowed},
+		apply:  func(validator Validator) Validator { return validator.Weekday(days, option...) },
+	}
+}
+
+func (BuiltinRules) Trim() Rule {
+	return rule{code: "trim", apply: Validator.Trim}
+}
+
+func (BuiltinRules) Lower() Rule {
+	return rule{code: "lower", apply: Validator.Lower}
+}
+
+func (BuiltinRules) NormalizeSpace() Rule {
+	return rule{code: "normalizespace", apply: Validator.NormalizeSpace}
+}
+
+func (BuiltinRules) StripControl() Rule {
+	return rule{code: "stripcontrol", apply: Validator.StripControl}
+}
+
+func (BuiltinRules) Transform(transform func(value any) any) Rule {
+	return rule{code: "transform", apply: func(validator Validator) Validator { return validator.Transform(transform) }}
+}
+
+func (BuiltinRules) AsInt(option ...ValidatorOption) Rule {
+	return rule{code: "int", apply: func(validator Validator) Validator { return validator.AsInt(option...) }}
+}
+
+func (BuiltinRules) AsFloat(option ...ValidatorOption) Rule {
+	return rule{code: "float", apply: func(validator Validator) Validator { return validator.AsFloat(option...) }}
+}
+
+func (BuiltinRules) AsBool(option ...ValidatorOption) Rule {
+	return rule{code: "bool", apply: func(validator Validator) Validator { return validator.AsBool(option...) }}
+}
+
+func (BuiltinRules) AsDuration(option ...ValidatorOption) Rule {
+	return rule{code: "duration", apply: func(validator Validator) Validator { return validator.AsDuration(option...) }}
+}
+
+func (BuiltinRules) AsTime(layout string, option ...ValidatorOption) Rule {
+	return rule{
+		code:   "time",
+		params: map[string]any{"layout": layout},
+		apply:  func(validator Validator) Validator { return validator.AsTime(layout, option...) },
+	}
+}
+
+func (BuiltinRules) Unwrap() Rule {
+	return rule{code: "unwrap", apply: Validator.Unwrap}
+}
+
+func (BuiltinRules) IsCtx(callback func(ctx context.Context, value any) error, option ...ValidatorOption) Rule {
+	return rule{code: "is", apply: func(validator Validator) Validator { return validator.IsCtx(callback, option...) }}
+}
+
+func (BuiltinRules) EqualField(name string, option ...ValidatorOption) Rule {
+	return rule{
+		code:   "equalfield",
+		params: map[string]any{"otherField": name},
+		apply:  func(validator Validator) Validator { return validator.EqualField(name, option...) },
+	}
+}
+
+func (BuiltinRules) NotEqualField(name string, option ...ValidatorOption) Rule {
+	return rule{
+		code:   "notequalfield",
+		params: map[string]any{"otherField": name},
+		apply:  func(validator Validator) Validator { return validator.NotEqualField(name, option...) },
+	}
+}
+
+func (BuiltinRules) GreaterThanField(name string, option ...ValidatorOption) Rule {
+	return rule{
+		code:   "greaterthanfield",
+		params: map[string]any{"otherField": name},
+		apply:  func(validator Validator) Validator { return validator.GreaterThanField(name, option...) },
+	}
+}
+
+func (BuiltinRules) LessThanField(name string, option ...ValidatorOption) Rule {
+	return rule{
+		code:   "lessthanfield",
+		params: map[string]any{"otherField": name},
+		apply:  func(validator Validator) Validator { return validator.LessThanField(name, option...) },
+	}
+}
+
+func (BuiltinRules) RequiredIf(name string, values []any, option ...ValidatorOption) Rule {
+	return rule{
+		code:   "requiredif",
+		params: map[string]any{"otherField": name, "values": values},
+		apply:  func(validator Validator) Validator { return validator.RequiredIf(name, values, option...) },
+	}
+}
+
+func (BuiltinRules) RequiredWith(names []string, option ...ValidatorOption) Rule {
+	return rule{
+		code:   "requiredwith",
+		params: map[string]any{"otherFields": stringsToAny(names)},
+		apply:  func(validator Validator) Validator { return validator.RequiredWith(names, option...) },
+	}
+}
+
+func (BuiltinRules) MinItems(min int, option ...ValidatorOption) Rule {
+	return rule{
+		code:   "minitems",
+		params: map[string]any{"min": min},
+		apply:  func(validator Validator) Validator { return validator.MinItems(min, option...) },
+	}
+}
+
+func (BuiltinRules) MaxItems(max int, option ...ValidatorOption) Rule {
+	return rule{
+		code:   "maxitems",
+		params: map[string]any{"max": max},
+		apply:  func(validator Validator) Validator { return validator.MaxItems(max, option...) },
+	}
+}
+
+func (BuiltinRules) Unique(key func(item any) any, option ...ValidatorOption) Rule {
+	var params map[string]any
+	if key != nil {
+		params = map[string]any{"key": key}
+	}
+	return rule{
+		code:   "unique",
+		params: params,
+		apply:  func(validator Validator) Validator { return validator.Unique(key, option...) },
+	}
+}
+
+func (BuiltinRules) Contains(element any, option ...ValidatorOption) Rule {
+	return rule{
+		code:   "contains",
+		params: map[string]any{"value": element},
+		apply:  func(validator Validator) Validator { return validator.Contains(element, option...) },
+	}
+}
+
+func (BuiltinRules) Subset(values []any, option ...ValidatorOption) Rule {
+	return rule{
+		code:   "subset",
+		params: map[string]any{"values": values},
+		apply:  func(validator Validator) Validator { return validator.Subset(values, option...) },
+	}
+}
+
+// Each is Dive, applying rules to every element of a slice, array or map.
+func (BuiltinRules) Each(rules ...Rule) Rule {
+	return Rules.Dive(rules...)
+}
+
+// Values is Dive, applying rules to every value of a map.
+func (BuiltinRules) Values(rules ...Rule) Rule {
+	return Rules.Dive(rules...)
+}
+
+// Keys applies rules to every key of a map, see Validator.Keys.
+func (BuiltinRules) Keys(rules ...Rule) Rule {
+	return rule{
+		code:   "keys",
+		params: map[string]any{"rules": rules},
+		apply:  func(validator Validator) Validator { return validator.Keys(rules...) },
+	}
+}
+
+// AnyOf passes when at least one of rules does, see Validator.AnyOf.
+func (BuiltinRules) AnyOf(rules []Rule, option ...ValidatorOption) Rule {
+	return rule{
+		code:   "anyof",
+		params: map[string]any{"rules": rules},
+		apply:  func(validator Validator) Validator { return validator.AnyOf(rules, option...) },
+	}
+}
+
+func (BuiltinRules) AllOf(rules []Rule, option ...ValidatorOption) Rule {
+	return rule{
+		code:   "allof",
+		params: map[string]any{"rules": rules},
+		apply:  func(validator Validator) Validator { return validator.AllOf(rules, option...) },
+	}
+}
+
+func (BuiltinRules) Not(negated Rule, option ...ValidatorOption) Rule {
+	return rule{
+		code:   "not",
+		params: map[string]any{"rule": negated},
+		apply:  func(validator Validator) Validator { return validator.Not(negated, option...) },
+	}
+}
+
+func (BuiltinRules) ExactlyOneOf(rules []Rule, option ...ValidatorOption) Rule {
+	return rule{
+		code:   "exactlyoneof",
+		params: map[string]any{"rules": rules},
+		apply:  func(validator Validator) Validator { return validator.ExactlyOneOf(rules, option...) },
+	}
+}
+
+// Dive applies rules to every element of a slice, array or map, see
+// Validator.Dive.
+func (BuiltinRules) Dive(rules ...Rule) Rule {
+	return rule{
+		code:   "dive",
+		params: map[string]any{"rules": rules},
+		apply: func(validator Validator) Validator {
+			return validator.Dive(func(item Validator) Validator {
+				return applyRules(item, rules)
+			})
+		},
+	}
+}
+
+func applyRules(validator Validator, rules []Rule) Validator {
+	for _, rule := range rules {
+		validator = rule.Apply(validator)
+	}
+	return validator
+}
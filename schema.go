@@ -0,0 +1,77 @@
+package gomal
+
+import "reflect"
+
+// ObjectSchema is a reusable set of rules for the fields of values of type T.
+// Declare it once, typically in a package variable, and validate many values
+// with it:
+//
+//	var userSchema = gomal.Schema[User]().
+//		Field("email", func(u User) any { return u.Email }, gomal.Rules.NotEmpty(), gomal.Rules.Email()).
+//		Field("age", func(u User) any { return u.Age }, gomal.Rules.GreaterThanOrEqual(17))
+//
+//	results := userSchema.Validate(user)
+//
+// A schema must not be changed once it is in use.
+type ObjectSchema[T any] struct {
+	fields []schemaField[T]
+}
+
+// SchemaField describes a field of an ObjectSchema.
+type SchemaField struct {
+	Name  string
+	Rules []Rule
+}
+
+type schemaField[T any] struct {
+	SchemaField
+
+	get func(value T) any
+}
+
+func Schema[T any]() *ObjectSchema[T] {
+	return &ObjectSchema[T]{}
+}
+
+// Field adds a field named name, reading its value with get and checking it
+// with rules in order.
+func (schema *ObjectSchema[T]) Field(name string, get func(value T) any, rules ...Rule) *ObjectSchema[T] {
+	schema.fields = append(schema.fields, schemaField[T]{
+		SchemaField: SchemaField{Name: name, Rules: rules},
+		get:         get,
+	})
+	return schema
+}
+
+// Fields returns the fields of the schema in declaration order.
+func (schema *ObjectSchema[T]) Fields() []SchemaField {
+	fields := make([]SchemaField, len(schema.fields))
+	for i, field := range schema.fields {
+		fields[i] = field.SchemaField
+	}
+	return fields
+}
+
+// Validators applies the schema to value, returning one validator per field
+// for Validate, ValidateContext, Check and the like.
+func (schema *ObjectSchema[T]) Validators(value T) []Validator {
+	validators := make([]Validator, len(schema.fields))
+	siblings := func(name string) (sibling, bool) {
+		for _, field := range schema.fields {
+			if field.Name == name {
+				return sibling{path: field.Name, value: reflect.ValueOf(field.get(value))}, true
+			}
+		}
+		return sibling{}, false
+	}
+	for i, field := range schema.fields {
+		validator := If(field.Name, field.get(value))
+		validator.siblings = siblings
+		validators[i] = applyRules(validator, field.Rules)
+	}
+	return validators
+}
+
+func (schema *ObjectSchema[T]) Validate(value T) []ValidationResult {
+	return Validate(schema.Validators(value)...)
+}
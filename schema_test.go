@@ -0,0 +1,71 @@
+package gomal_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ItsMalma/gomal"
+)
+
+type user struct {
+	Email    string
+	Nickname *string
+	Age      int
+	Tags     []string
+}
+
+var userSchema = gomal.Schema[user]().
+	Field("email", func(u user) any { return u.Email }, gomal.Rules.NotEmpty(), gomal.Rules.Email()).
+	Field("nickname", func(u user) any { return u.Nickname }, gomal.Rules.Unwrap(), gomal.Rules.Length(2, 8)).
+	Field("age", func(u user) any { return u.Age }, gomal.Rules.Between(17, 65)).
+	Field("tags", func(u user) any { return u.Tags }, gomal.Rules.Dive(gomal.Rules.NotEmpty()))
+
+func TestSchema(t *testing.T) {
+	nickname := "m"
+	tests := []struct {
+		name    string
+		value   user
+		results []gomal.ValidationResult
+	}{
+		{
+			name:    "success",
+			value:   user{Email: "malma@example.com", Age: 20, Tags: []string{"go"}},
+			results: []gomal.ValidationResult{},
+		},
+		{
+			name:  "failed",
+			value: user{Email: "", Nickname: &nickname, Age: 70, Tags: []string{"go", ""}},
+			results: []gomal.ValidationResult{
+				{Name: "email", Messages: []string{"email should not be empty.", "email is not a valid email address"}},
+				{Name: "nickname", Messages: []string{"nickname must be between 2 and 8 characters. You entered 1 characters"}},
+				{Name: "age", Messages: []string{"age must be between 17 and 65."}},
+				{Name: "tags[1]", Messages: []string{"tags[1] should not be empty."}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			results := userSchema.Validate(test.value)
+			if !reflect.DeepEqual(results, test.results) {
+				tt.Fatalf("expected %#v but got %#v instead", test.results, results)
+			}
+		})
+	}
+}
+
+func TestSchemaFields(t *testing.T) {
+	fields := userSchema.Fields()
+	if len(fields) != 4 {
+		t.Fatalf("expected 4 fields but got %v instead", len(fields))
+	}
+
+	nickname := fields[1]
+	if nickname.Name != "nickname" || len(nickname.Rules) != 2 {
+		t.Fatalf("unexpected field %#v", nickname)
+	}
+	length := nickname.Rules[1]
+	if length.Code() != "length" || !reflect.DeepEqual(length.Params(), map[string]any{"min": 2, "max": 8}) {
+		t.Fatalf("unexpected rule %v %#v", length.Code(), length.Params())
+	}
+}
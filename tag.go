@@ -0,0 +1,823 @@
+package gomal
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type structField struct {
+	index []int
+	name  string
+	rules []Rule
+	// dive is set when the rules already descend into the elements.
+	dive bool
+}
+
+var structFieldsCache sync.Map // map[reflect.Type][]structField
+
+// tagRules maps every rule name accepted in the gomal struct tag to a function
+// that parses its parameter for the given field type.
+var tagRules = map[string]func(param string, fieldType reflect.Type) (Rule, error){
+	"notnil": func(param string, fieldType reflect.Type) (Rule, error) {
+		return Rules.NotNil(), nil
+	},
+	"nil": func(param string, fieldType reflect.Type) (Rule, error) {
+		return Rules.Nil(), nil
+	},
+	"notempty": func(param string, fieldType reflect.Type) (Rule, error) {
+		return Rules.NotEmpty(), nil
+	},
+	"empty": func(param string, fieldType reflect.Type) (Rule, error) {
+		return Rules.Empty(), nil
+	},
+	"email": func(param string, fieldType reflect.Type) (Rule, error) {
+		switch param {
+		case "":
+			return Rules.Email(), nil
+		case "strict":
+			return Rules.StrictEmail(), nil
+		}
+		return nil, fmt.Errorf("expected strict but got %q", param)
+	},
+	"url": func(param string, fieldType reflect.Type) (Rule, error) {
+		if param == "" {
+			return Rules.URL(nil), nil
+		}
+		return Rules.URL(strings.Split(param, "|")), nil
+	},
+	"uuid": func(param string, fieldType reflect.Type) (Rule, error) {
+		if param == "" {
+			return Rules.UUID(0), nil
+		}
+		version, err := strconv.Atoi(param)
+		if err != nil {
+			return nil, err
+		}
+		return Rules.UUID(version), nil
+	},
+	"ip": func(param string, fieldType reflect.Type) (Rule, error) {
+		return Rules.IP(), nil
+	},
+	"ipv4": func(param string, fieldType reflect.Type) (Rule, error) {
+		return Rules.IPv4(), nil
+	},
+	"ipv6": func(param string, fieldType reflect.Type) (Rule, error) {
+		return Rules.IPv6(), nil
+	},
+	"cidr": func(param string, fieldType reflect.Type) (Rule, error) {
+		return Rules.CIDR(), nil
+	},
+	"hostname": func(param string, fieldType reflect.Type) (Rule, error) {
+		return Rules.Hostname(), nil
+	},
+	"fqdn": func(param string, fieldType reflect.Type) (Rule, error) {
+		return Rules.FQDN(), nil
+	},
+	"macaddress": func(param string, fieldType reflect.Type) (Rule, error) {
+		return Rules.MACAddress(), nil
+	},
+	"e164phone": func(param string, fieldType reflect.Type) (Rule, error) {
+		return Rules.E164Phone(), nil
+	},
+	"iso8601date": func(param string, fieldType reflect.Type) (Rule, error) {
+		return Rules.ISO8601Date(), nil
+	},
+	"rfc3339": func(param string, fieldType reflect.Type) (Rule, error) {
+		return Rules.RFC3339(), nil
+	},
+	"base64": func(param string, fieldType reflect.Type) (Rule, error) {
+		return Rules.Base64(), nil
+	},
+	"hex": func(param string, fieldType reflect.Type) (Rule, error) {
+		return Rules.Hex(), nil
+	},
+	"json": func(param string, fieldType reflect.Type) (Rule, error) {
+		return Rules.JSON(), nil
+	},
+	"semver": func(param string, fieldType reflect.Type) (Rule, error) {
+		return Rules.Semver(), nil
+	},
+	"slug": func(param string, fieldType reflect.Type) (Rule, error) {
+		return Rules.Slug(), nil
+	},
+	"trim": func(param string, fieldType reflect.Type) (Rule, error) {
+		return Rules.Trim(), nil
+	},
+	"lower": func(param string, fieldType reflect.Type) (Rule, error) {
+		return Rules.Lower(), nil
+	},
+	"normalizespace": func(param string, fieldType reflect.Type) (Rule, error) {
+		return Rules.NormalizeSpace(), nil
+	},
+	"stripcontrol": func(param string, fieldType reflect.Type) (Rule, error) {
+		return Rules.StripControl(), nil
+	},
+	"unwrap": func(param string, fieldType reflect.Type) (Rule, error) {
+		return Rules.Unwrap(), nil
+	},
+	"equal": func(param string, fieldType reflect.Type) (Rule, error) {
+		another, err := parseTagValue(param, fieldType)
+		if err != nil {
+			return nil, err
+		}
+		return Rules.Equal(another), nil
+	},
+	"notequal": func(param string, fieldType reflect.Type) (Rule, error) {
+		another, err := parseTagValue(param, fieldType)
+		if err != nil {
+			return nil, err
+		}
+		return Rules.NotEqual(another), nil
+	},
+	"oneof": func(param string, fieldType reflect.Type) (Rule, error) {
+		if param == "" {
+			return Rules.OneOf(), nil
+		}
+		values, err := parseTagValues(param, fieldType)
+		if err != nil {
+			return nil, err
+		}
+		return Rules.OneOf(values...), nil
+	},
+	"notoneof": func(param string, fieldType reflect.Type) (Rule, error) {
+		values, err := parseTagValues(param, fieldType)
+		if err != nil {
+			return nil, err
+		}
+		return Rules.NotOneOf(values...), nil
+	},
+	"length": func(param string, fieldType reflect.Type) (Rule, error) {
+		min, max, err := parseTagRange(param, func(s string) (int, error) { return strconv.Atoi(s) })
+		if err != nil {
+			return nil, err
+		}
+		return Rules.Length(min, max), nil
+	},
+	"bytelength": func(param string, fieldType reflect.Type) (Rule, error) {
+		min, max, err := parseTagRange(param, func(s string) (int, error) { return strconv.Atoi(s) })
+		if err != nil {
+			return nil, err
+		}
+		return Rules.ByteLength(min, max), nil
+	},
+	"minlength": func(param string, fieldType reflect.Type) (Rule, error) {
+		min, err := strconv.Atoi(param)
+		if err != nil {
+			return nil, err
+		}
+		return Rules.MinLength(min), nil
+	},
+	"maxlength": func(param string, fieldType reflect.Type) (Rule, error) {
+		max, err := strconv.Atoi(param)
+		if err != nil {
+			return nil, err
+		}
+		return Rules.MaxLength(max), nil
+	},
+	"lessthan": func(param string, fieldType reflect.Type) (Rule, error) {
+		another, err := parseTagNumber(param, fieldType)
+		if err != nil {
+			return nil, err
+		}
+		return Rules.LessThan(another), nil
+	},
+	"lessthanorequal": func(param string, fieldType reflect.Type) (Rule, error) {
+		another, err := parseTagNumber(param, fieldType)
+		if err != nil {
+			return nil, err
+		}
+		return Rules.LessThanOrEqual(another), nil
+	},
+	"greaterthan": func(param string, fieldType reflect.Type) (Rule, error) {
+		another, err := parseTagNumber(param, fieldType)
+		if err != nil {
+			return nil, err
+		}
+		return Rules.GreaterThan(another), nil
+	},
+	"greaterthanorequal": func(param string, fieldType reflect.Type) (Rule, error) {
+		another, err := parseTagNumber(param, fieldType)
+		if err != nil {
+			return nil, err
+		}
+		return Rules.GreaterThanOrEqual(another), nil
+	},
+	"between": func(param string, fieldType reflect.Type) (Rule, error) {
+		min, max, err := parseTagRange(param, func(s string) (any, error) { return parseTagNumber(s, fieldType) })
+		if err != nil {
+			return nil, err
+		}
+		return Rules.Between(min, max), nil
+	},
+	"positive": func(param string, fieldType reflect.Type) (Rule, error) {
+		return Rules.Positive(), nil
+	},
+	"negative": func(param string, fieldType reflect.Type) (Rule, error) {
+		return Rules.Negative(), nil
+	},
+	"nonzero": func(param string, fieldType reflect.Type) (Rule, error) {
+		return Rules.NonZero(), nil
+	},
+	"finite": func(param string, fieldType reflect.Type) (Rule, error) {
+		return Rules.Finite(), nil
+	},
+	"multipleof": func(param string, fieldType reflect.Type) (Rule, error) {
+		step, err := parseTagNumber(param, fieldType)
+		if err != nil {
+			return nil, err
+		}
+		if rat, _ := toRat(reflect.ValueOf(step)); rat == nil || rat.Sign() == 0 {
+			return nil, fmt.Errorf("%q is not a non-zero finite number", param)
+		}
+		return Rules.MultipleOf(step), nil
+	},
+	"precision": func(param string, fieldType reflect.Type) (Rule, error) {
+		scale, err := strconv.Atoi(param)
+		if err != nil {
+			return nil, err
+		}
+		if scale < 0 {
+			return nil, fmt.Errorf("%v is a negative scale", scale)
+		}
+		return Rules.Precision(scale), nil
+	},
+	"regexp": func(param string, fieldType reflect.Type) (Rule, error) {
+		compiled, err := compileRegexp(param)
+		if err != nil {
+			return nil, err
+		}
+		return Rules.RegExp(compiled), nil
+	},
+	"before": func(param string, fieldType reflect.Type) (Rule, error) {
+		limit, err := time.Parse(time.RFC3339, param)
+		if err != nil {
+			return nil, err
+		}
+		return Rules.Before(limit), nil
+	},
+	"after": func(param string, fieldType reflect.Type) (Rule, error) {
+		limit, err := time.Parse(time.RFC3339, param)
+		if err != nil {
+			return nil, err
+		}
+		return Rules.After(limit), nil
+	},
+	"betweentimes": func(param string, fieldType reflect.Type) (Rule, error) {
+		min, max, err := parseTagRange(param, func(s string) (time.Time, error) { return time.Parse(time.RFC3339, s) })
+		if err != nil {
+			return nil, err
+		}
+		return Rules.BetweenTimes(min, max), nil
+	},
+	"within": func(param string, fieldType reflect.Type) (Rule, error) {
+		duration, err := time.ParseDuration(param)
+		if err != nil {
+			return nil, err
+		}
+		return Rules.Within(duration), nil
+	},
+	"notinpast": func(param string, fieldType reflect.Type) (Rule, error) {
+		return Rules.NotInPast(), nil
+	},
+	"notinfuture": func(param string, fieldType reflect.Type) (Rule, error) {
+		return Rules.NotInFuture(), nil
+	},
+	"minage": func(param string, fieldType reflect.Type) (Rule, error) {
+		years, err := strconv.Atoi(param)
+		if err != nil {
+			return nil, err
+		}
+		return Rules.MinAge(years), nil
+	},
+	"weekday": func(param string, fieldType reflect.Type) (Rule, error) {
+		days := []time.Weekday{}
+		for _, name := range strings.Split(param, "|") {
+			day, ok := weekdays[strings.ToLower(name)]
+			if !ok {
+				return nil, fmt.Errorf("unknown weekday %q", name)
+			}
+			days = append(days, day)
+		}
+		return Rules.Weekday(days), nil
+	},
+	"minitems": func(param string, fieldType reflect.Type) (Rule, error) {
+		min, err := strconv.Atoi(param)
+		if err != nil {
+			return nil, err
+		}
+		return Rules.MinItems(min), nil
+	},
+	"maxitems": func(param string, fieldType reflect.Type) (Rule, error) {
+		max, err := strconv.Atoi(param)
+		if err != nil {
+			return nil, err
+		}
+		return Rules.MaxItems(max), nil
+	},
+	"unique": func(param string, fieldType reflect.Type) (Rule, error) {
+		return Rules.Unique(nil), nil
+	},
+	"contains": func(param string, fieldType reflect.Type) (Rule, error) {
+		if fieldType.Kind() == reflect.String {
+			return Rules.Contains(param), nil
+		}
+		if fieldType.Kind() != reflect.Array && fieldType.Kind() != reflect.Slice {
+			return nil, fmt.Errorf("%v is not a string, slice or array", fieldType)
+		}
+		element, err := parseTagValue(param, fieldType.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return Rules.Contains(element), nil
+	},
+	"subset": func(param string, fieldType reflect.Type) (Rule, error) {
+		if fieldType.Kind() != reflect.Array && fieldType.Kind() != reflect.Slice {
+			return nil, fmt.Errorf("%v is not a slice or array", fieldType)
+		}
+		values, err := parseTagValues(param, fieldType.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return Rules.Subset(values), nil
+	},
+	"equalfield": func(param string, fieldType reflect.Type) (Rule, error) {
+		return Rules.EqualField(param), nil
+	},
+	"notequalfield": func(param string, fieldType reflect.Type) (Rule, error) {
+		return Rules.NotEqualField(param), nil
+	},
+	"greaterthanfield": func(param string, fieldType reflect.Type) (Rule, error) {
+		return Rules.GreaterThanField(param), nil
+	},
+	"lessthanfield": func(param string, fieldType reflect.Type) (Rule, error) {
+		return Rules.LessThanField(param), nil
+	},
+	"requiredif": func(param string, fieldType reflect.Type) (Rule, error) {
+		params := strings.Split(param, "|")
+		if len(params) < 2 {
+			return nil, fmt.Errorf("expected field|value but got %q", param)
+		}
+		values := make([]any, len(params)-1)
+		for i, value := range params[1:] {
+			values[i] = value
+		}
+		return Rules.RequiredIf(params[0], values), nil
+	},
+	"requiredwith": func(param string, fieldType reflect.Type) (Rule, error) {
+		if param == "" {
+			return nil, fmt.Errorf("expected field names")
+		}
+		return Rules.RequiredWith(strings.Split(param, "|")), nil
+	},
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// ValidateStruct validates every exported field of v (a struct or a pointer to
+// one) using the rules declared in its gomal tag, e.g.
+//
+//	Email string `json:"email" gomal:"notempty,email,length=3|64"`
+//	Tags  []string `json:"tags" gomal:"notempty,dive,maxlength=20"`
+//
+// Rules are separated by commas and run in order, parameters follow "=" and
+// ranges are written as "min|max". A parameter holding commas is quoted with
+// single quotes, doubling the quotes inside, as in "regexp='^[A-Z]{2,4}$'".
+// Rules after "dive" apply to every element of a slice, array or map. Cross-field rules name sibling fields, as in
+// "equalfield=Password" or "requiredif=Country|DE|FR". The result name is
+// taken from the json tag when present, otherwise from the field name. Nested
+// structs, including the ones held in slices, arrays and maps, are validated
+// too and reported with paths such as "order.items[3].sku". The invariants of
+// values implementing Validatable, v included, are merged in. Malformed tags
+// panic, see CheckTags.
+//
+// Transformers such as "trim" and "lower" clean the value for the following
+// rules. When v is a pointer, the cleaned values are stored back into its
+// fields.
+func ValidateStruct(v any) []ValidationResult {
+	return Validate(rootStructValidators("ValidateStruct", v)...)
+}
+
+// ValidateStructWith is ValidateStruct rendering messages with translator, see
+// ValidateWith.
+func ValidateStructWith(translator Translator, v any) []ValidationResult {
+	return ValidateWith(translator, rootStructValidators("ValidateStructWith", v)...)
+}
+
+// ValidateStructContext is ValidateStruct passing ctx to the rules added with
+// IsCtx and to the ValidateContext methods of the values, see ValidateContext.
+// It returns the context's error as soon as ctx is done.
+func ValidateStructContext(ctx context.Context, v any) ([]ValidationResult, error) {
+	return ValidateContext(ctx, rootStructValidators("ValidateStructContext", v)...)
+}
+
+// StructViolations is ValidateStruct returning the structured violations, see
+// Violations.
+func StructViolations(v any) []Violation {
+	return Violations(rootStructValidators("StructViolations", v)...)
+}
+
+// StructViolationsWith is StructViolations rendering messages with translator,
+// see ValidateWith.
+func StructViolationsWith(translator Translator, v any) []Violation {
+	return ViolationsWith(translator, rootStructValidators("StructViolationsWith", v)...)
+}
+
+// rootStructValidators returns a validator for v, which checks the invariants
+// of v when it is Validatable, holding the validators of its fields. It returns
+// none when v is passed from its own Validate method while its fields are
+// checked already, see Validatable.
+func rootStructValidators(caller string, v any) []Validator {
+	if value := indirect(reflect.ValueOf(v)); value.IsValid() {
+		if _, fields := checkingInvariants(value.Interface()); fields {
+			return []Validator{}
+		}
+	}
+	beforeValidate(reflect.ValueOf(v), nil)
+	root := If("", v)
+	reflectValue := indirect(root.reflectValue)
+	if !reflectValue.IsValid() {
+		return []Validator{}
+	}
+	if reflectValue.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("gomal: %v expects a struct but got %v", caller, reflectValue.Kind()))
+	}
+	root.visiting = map[visit]bool{}
+	if root.reflectValue.Kind() == reflect.Pointer {
+		root.visiting[visit{root.reflectValue.Pointer(), root.valueType}] = true
+	}
+	root.children = structValidators("", reflectValue, root.visiting)
+	root.fields = true
+	return []Validator{root}
+}
+
+// visit identifies a pointer being descended into, see Validator.visiting.
+type visit struct {
+	pointer   uintptr
+	valueType reflect.Type
+}
+
+func structValidators(prefix string, reflectValue reflect.Value, visiting map[visit]bool) []Validator {
+	fields := getStructFields(reflectValue.Type())
+	validators := make([]Validator, 0, len(fields))
+	siblings := structSiblings(prefix, reflectValue)
+	for _, field := range fields {
+		fieldValue, err := reflectValue.FieldByIndexErr(field.index)
+		if err != nil {
+			// The field is promoted through a nil embedded pointer.
+			continue
+		}
+		beforeValidate(fieldValue, visiting)
+		validator := If(fieldPath(prefix, field.name), fieldValue.Interface())
+		validator.siblings = siblings
+		validator.visiting = visiting
+		validator = applyRules(validator, field.rules)
+		if validator.transformed && fieldValue.CanSet() {
+			// Store the cleaned value when the struct was passed by pointer.
+			assign(fieldValue, validator.value)
+		}
+		if !field.dive {
+			validator = descend(validator)
+		}
+		validators = append(validators, validator)
+	}
+	return validators
+}
+
+// descend adds the validators of the nested structs found in the validator's
+// value. A pointer to a struct already being descended into, as in a cyclic
+// list, is not descended into again.
+func descend(validator Validator) Validator {
+	if validator.stop {
+		return validator
+	}
+
+	if validator.reflectValue.Kind() == reflect.Pointer && !validator.reflectValue.IsNil() {
+		if validator.visiting == nil {
+			validator.visiting = map[visit]bool{}
+		}
+		key := visit{validator.reflectValue.Pointer(), validator.valueType}
+		if validator.visiting[key] {
+			return validator
+		}
+		validator.visiting[key] = true
+		defer delete(validator.visiting, key)
+	}
+
+	value := indirect(validator.reflectValue)
+	switch value.Kind() {
+	case reflect.Struct:
+		validator.children = append(validator.children, structValidators(validator.name, value, validator.visiting)...)
+		validator.fields = true
+	case reflect.Array, reflect.Slice, reflect.Map:
+		if containsStruct(value.Type().Elem()) {
+			if value.Kind() == reflect.Map {
+				for _, key := range value.MapKeys() {
+					beforeValidate(value.MapIndex(key), validator.visiting)
+				}
+			} else {
+				for i := 0; i < value.Len(); i++ {
+					beforeValidate(value.Index(i), validator.visiting)
+				}
+			}
+			validator = validator.Dive(descend)
+		}
+	}
+	return validator
+}
+
+// containsStruct reports whether values of fieldType can hold a struct to
+// descend into.
+func containsStruct(fieldType reflect.Type) bool {
+	switch fieldType.Kind() {
+	case reflect.Struct:
+		return true
+	case reflect.Pointer, reflect.Array, reflect.Slice, reflect.Map:
+		return containsStruct(fieldType.Elem())
+	}
+	return false
+}
+
+func getStructFields(structType reflect.Type) []structField {
+	fields, err := loadStructFields(structType)
+	if err != nil {
+		panic(err.Error())
+	}
+	return fields
+}
+
+// loadStructFields parses the gomal tags of structType once and caches them.
+func loadStructFields(structType reflect.Type) ([]structField, error) {
+	if fields, ok := structFieldsCache.Load(structType); ok {
+		return fields.([]structField), nil
+	}
+
+	fields := []structField{}
+	for _, field := range reflect.VisibleFields(structType) {
+		if !field.IsExported() || field.Anonymous {
+			continue
+		}
+		tag, ok := field.Tag.Lookup("gomal")
+		if tag == "-" || (!ok && !containsStruct(field.Type)) {
+			continue
+		}
+
+		rules, dive, err := parseTag(tag, field.Type)
+		if err == nil {
+			err = checkSiblings(structType, field.Type, rules)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("gomal: invalid tag on %v.%v: %w", structType, field.Name, err)
+		}
+		fields = append(fields, structField{
+			index: field.Index,
+			name:  fieldName(field),
+			rules: rules,
+			dive:  dive,
+		})
+	}
+
+	structFieldsCache.Store(structType, fields)
+	return fields, nil
+}
+
+// CheckTags parses the gomal tags of the type of v, a struct or a pointer to
+// one, and of the structs it contains. It returns the first malformed tag, such
+// as an unknown rule, an invalid pattern or a missing sibling field, so it can
+// be called at startup instead of having ValidateStruct panic on the first
+// request.
+func CheckTags(v any) error {
+	structType := reflect.TypeOf(v)
+	for structType != nil && structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+	if structType == nil || structType.Kind() != reflect.Struct {
+		return fmt.Errorf("gomal: CheckTags expects a struct but got %v", structType)
+	}
+	return checkTags(structType, map[reflect.Type]bool{})
+}
+
+func checkTags(fieldType reflect.Type, visited map[reflect.Type]bool) error {
+	for fieldType.Kind() != reflect.Struct {
+		switch fieldType.Kind() {
+		case reflect.Pointer, reflect.Array, reflect.Slice, reflect.Map:
+			fieldType = fieldType.Elem()
+		default:
+			return nil
+		}
+	}
+	if visited[fieldType] {
+		return nil
+	}
+	visited[fieldType] = true
+
+	fields, err := loadStructFields(fieldType)
+	if err != nil {
+		return err
+	}
+	for _, field := range fields {
+		if err := checkTags(fieldType.FieldByIndex(field.index).Type, visited); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func fieldName(field reflect.StructField) string {
+	if jsonTag, ok := field.Tag.Lookup("json"); ok {
+		name, _, _ := strings.Cut(jsonTag, ",")
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// parseTag parses the rules of a gomal tag. It reports whether the tag uses
+// dive, in which case the remaining rules are applied to every element.
+func parseTag(tag string, fieldType reflect.Type) ([]Rule, bool, error) {
+	for fieldType.Kind() == reflect.Pointer {
+		fieldType = fieldType.Elem()
+	}
+
+	names, err := splitTag(tag)
+	if err != nil {
+		return nil, false, err
+	}
+	rules := []Rule{}
+	for i, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		name, param, _ := strings.Cut(name, "=")
+		param, err := unquoteTagParam(param)
+		if err != nil {
+			return nil, false, fmt.Errorf("rule %q: %w", name, err)
+		}
+		if name == "dive" {
+			switch fieldType.Kind() {
+			case reflect.Array, reflect.Slice, reflect.Map:
+			default:
+				return nil, false, fmt.Errorf("dive requires a slice, array or map but got %v", fieldType)
+			}
+			itemRules, itemDive, err := parseTag(strings.Join(names[i+1:], ","), fieldType.Elem())
+			if err != nil {
+				return nil, false, err
+			}
+			if !itemDive {
+				itemRules = append(itemRules, rule{code: "descend", apply: descend})
+			}
+			return append(rules, Rules.Dive(itemRules...)), true, nil
+		}
+
+		parse, ok := tagRules[name]
+		if !ok {
+			factory, ok := registeredRule(name)
+			if !ok {
+				return nil, false, fmt.Errorf("unknown rule %q", name)
+			}
+			parse = func(param string, fieldType reflect.Type) (Rule, error) { return factory(param) }
+		}
+		rule, err := parse(param, fieldType)
+		if err != nil {
+			return nil, false, fmt.Errorf("rule %q: %w", name, err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, false, nil
+}
+
+// splitTag splits a gomal tag into its rules at the commas that are not in a
+// quoted parameter, such as regexp='^[A-Z]{2,4}$'.
+func splitTag(tag string) ([]string, error) {
+	names := []string{}
+	start, quoted := 0, false
+	for i := 0; i < len(tag); i++ {
+		switch {
+		case quoted && tag[i] == '\'' && i+1 < len(tag) && tag[i+1] == '\'':
+			// A doubled quote stands for a quote.
+			i++
+		case quoted && tag[i] == '\'':
+			quoted = false
+		case !quoted && tag[i] == '\'' && i > start && tag[i-1] == '=':
+			quoted = true
+		case !quoted && tag[i] == ',':
+			names = append(names, tag[start:i])
+			start = i + 1
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("unterminated quote in %q", tag[start:])
+	}
+	return append(names, tag[start:]), nil
+}
+
+// unquoteTagParam returns param without its quotes when it is quoted, with
+// each doubled quote inside replaced by a single one.
+func unquoteTagParam(param string) (string, error) {
+	if !strings.HasPrefix(param, "'") {
+		return param, nil
+	}
+	if len(param) < 2 || !strings.HasSuffix(param, "'") {
+		return "", fmt.Errorf("expected a closing quote at the end of %v", param)
+	}
+	return strings.ReplaceAll(param[1:len(param)-1], "''", "'"), nil
+}
+
+func parseTagRange[T any](param string, parse func(string) (T, error)) (T, T, error) {
+	var min, max T
+	minParam, maxParam, ok := strings.Cut(param, "|")
+	if !ok {
+		return min, max, fmt.Errorf("expected min|max but got %q", param)
+	}
+	min, err := parse(minParam)
+	if err != nil {
+		return min, max, err
+	}
+	max, err = parse(maxParam)
+	return min, max, err
+}
+
+// parseTagNumber parses param into the int64, uint64 or float64 expected by the
+// numerical rules for a field of the given type. Decimal strings and big
+// numbers are compared with the decimal param itself.
+func parseTagNumber(param string, fieldType reflect.Type) (any, error) {
+	if fieldType.Kind() == reflect.String || fieldType == bigIntType || fieldType == bigRatType || fieldType == bigFloatType {
+		if !decimalRegexp.MatchString(param) {
+			return nil, fmt.Errorf("%q is not a decimal", param)
+		}
+		return param, nil
+	}
+	switch fieldType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.ParseInt(param, 10, 64)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.ParseUint(param, 10, 64)
+	case reflect.Float32, reflect.Float64:
+		return strconv.ParseFloat(param, 64)
+	}
+	return nil, fmt.Errorf("%v is not a numerical type", fieldType)
+}
+
+// parseTagValues parses a list of values separated by "|", see parseTagValue.
+func parseTagValues(param string, fieldType reflect.Type) ([]any, error) {
+	values := []any{}
+	for _, value := range strings.Split(param, "|") {
+		parsed, err := parseTagValue(value, fieldType)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, parsed)
+	}
+	return values, nil
+}
+
+// parseTagValue parses param into a value of exactly the given type so it can
+// be compared with reflect.DeepEqual.
+func parseTagValue(param string, fieldType reflect.Type) (any, error) {
+	value := reflect.New(fieldType).Elem()
+	switch fieldType.Kind() {
+	case reflect.String:
+		value.SetString(param)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(param)
+		if err != nil {
+			return nil, err
+		}
+		value.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(param, 10, fieldType.Bits())
+		if err != nil {
+			return nil, err
+		}
+		value.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(param, 10, fieldType.Bits())
+		if err != nil {
+			return nil, err
+		}
+		value.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(param, fieldType.Bits())
+		if err != nil {
+			return nil, err
+		}
+		value.SetFloat(parsed)
+	default:
+		return nil, fmt.Errorf("%v cannot be compared with a tag value", fieldType)
+	}
+	return value.Interface(), nil
+}
@@ -0,0 +1,217 @@
+package gomal_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ItsMalma/gomal"
+)
+
+type signUpRequest struct {
+	Email    string  `json:"email" gomal:"notempty,email"`
+	Username string  `json:"username,omitempty" gomal:"length=3|16"`
+	Age      int     `gomal:"greaterthanorequal=17"`
+	Role     string  `json:"role" gomal:"equal=member"`
+	Nickname *string `json:"nickname" gomal:"unwrap,minlength=2"`
+	Ignored  string  `json:"ignored"`
+	internal string  `gomal:"notempty"`
+}
+
+func TestValidateStruct(t *testing.T) {
+	nickname := "x"
+	tests := []struct {
+		name    string
+		value   any
+		results []gomal.ValidationResult
+	}{
+		{
+			name: "success",
+			value: signUpRequest{
+				Email:    "malma@example.com",
+				Username: "malma",
+				Age:      17,
+				Role:     "member",
+			},
+			results: []gomal.ValidationResult{},
+		},
+		{
+			name: "success (pointer)",
+			value: &signUpRequest{
+				Email:    "malma@example.com",
+				Username: "malma",
+				Age:      20,
+				Role:     "member",
+			},
+			results: []gomal.ValidationResult{},
+		},
+		{
+			name: "failed",
+			value: signUpRequest{
+				Email:    "",
+				Username: "ma",
+				Age:      16,
+				Role:     "admin",
+				Nickname: &nickname,
+			},
+			results: []gomal.ValidationResult{
+				{Name: "email", Messages: []string{"email should not be empty.", "email is not a valid email address"}},
+				{Name: "username", Messages: []string{"username must be between 3 and 16 characters. You entered 2 characters"}},
+				{Name: "Age", Messages: []string{"Age must be greater than or equal to 17."}},
+				{Name: "role", Messages: []string{"role should be equal to member."}},
+				{Name: "nickname", Messages: []string{"The length of nickname must be at least 2 characters. You entered 1 characters."}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			results := gomal.ValidateStruct(test.value)
+			if !reflect.DeepEqual(results, test.results) {
+				tt.Fatalf("expected %#v but got %#v instead", test.results, results)
+			}
+		})
+	}
+}
+
+func TestValidateStructInvalidTag(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected panic for unknown rule")
+		}
+	}()
+
+	gomal.ValidateStruct(struct {
+		X string `gomal:"unknown"`
+	}{})
+}
+
+func TestValidateStructQuotedParam(t *testing.T) {
+	type country struct {
+		Code  string   `json:"code" gomal:"regexp='^[A-Z]{2,3}$',notempty"`
+		Names []string `json:"names" gomal:"dive,regexp='^[a-z'' ]+$'"`
+	}
+
+	tests := []struct {
+		name    string
+		value   country
+		results []gomal.ValidationResult
+	}{
+		{
+			name:    "success",
+			value:   country{Code: "IDN", Names: []string{"cote d'ivoire"}},
+			results: []gomal.ValidationResult{},
+		},
+		{
+			name:  "failed",
+			value: country{Code: "INDO", Names: []string{"Indonesia"}},
+			results: []gomal.ValidationResult{
+				{Name: "code", Messages: []string{"code is not in the correct format"}},
+				{Name: "names[0]", Messages: []string{"names[0] is not in the correct format"}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			results := gomal.ValidateStruct(test.value)
+			if !reflect.DeepEqual(results, test.results) {
+				tt.Fatalf("expected %#v but got %#v instead", test.results, results)
+			}
+		})
+	}
+
+	if err := gomal.CheckTags(struct {
+		Code string `gomal:"regexp='^[A-Z]{2,3}$"`
+	}{}); err == nil {
+		t.Fatalf("expected error for unterminated quote")
+	}
+}
+
+type orderItem struct {
+	SKU      string `json:"sku" gomal:"notempty"`
+	Quantity int    `json:"quantity" gomal:"greaterthan=0"`
+}
+
+type orderRequest struct {
+	Customer struct {
+		Name string `json:"name" gomal:"notempty"`
+	} `json:"customer"`
+	Items    []orderItem           `json:"items" gomal:"notempty"`
+	Tags     []string              `json:"tags" gomal:"dive,maxlength=3"`
+	Extras   map[string]*orderItem `json:"extras"`
+	Comments []string              `json:"comments"`
+}
+
+func TestValidateStructNested(t *testing.T) {
+	value := orderRequest{
+		Items: []orderItem{{SKU: "A-1", Quantity: 1}, {SKU: "", Quantity: 0}},
+		Tags:  []string{"new", "sale!"},
+		Extras: map[string]*orderItem{
+			"gift": {SKU: "", Quantity: 1},
+			"none": nil,
+		},
+	}
+
+	expected := []gomal.ValidationResult{
+		{Name: "customer.name", Messages: []string{"customer.name should not be empty."}},
+		{Name: "items[1].sku", Messages: []string{"items[1].sku should not be empty."}},
+		{Name: "items[1].quantity", Messages: []string{"items[1].quantity must be greater than 0."}},
+		{Name: "tags[1]", Messages: []string{"The length of tags[1] must be 3 characters or fewer. You entered 5 characters."}},
+		{Name: `extras["gift"].sku`, Messages: []string{`extras["gift"].sku should not be empty.`}},
+	}
+	results := gomal.ValidateStruct(value)
+	if !reflect.DeepEqual(results, expected) {
+		t.Fatalf("expected %#v but got %#v instead", expected, results)
+	}
+}
+
+type listNode struct {
+	Name     string      `json:"name" gomal:"notempty"`
+	Next     *listNode   `json:"next"`
+	Children []*listNode `json:"children"`
+}
+
+func TestValidateStructCycle(t *testing.T) {
+	loop := &listNode{}
+	loop.Next = loop
+
+	parent := &listNode{Name: "parent"}
+	child := &listNode{Next: parent}
+	parent.Children = []*listNode{child}
+
+	shared := &listNode{}
+
+	tests := []struct {
+		name    string
+		value   any
+		results []gomal.ValidationResult
+	}{
+		{
+			name:    "self reference",
+			value:   loop,
+			results: []gomal.ValidationResult{{Name: "name", Messages: []string{"name should not be empty."}}},
+		},
+		{
+			name:    "cycle through a slice",
+			value:   parent,
+			results: []gomal.ValidationResult{{Name: "children[0].name", Messages: []string{"children[0].name should not be empty."}}},
+		},
+		{
+			name:  "shared pointer",
+			value: listNode{Name: "root", Next: shared, Children: []*listNode{shared}},
+			results: []gomal.ValidationResult{
+				{Name: "next.name", Messages: []string{"next.name should not be empty."}},
+				{Name: "children[0].name", Messages: []string{"children[0].name should not be empty."}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			results := gomal.ValidateStruct(test.value)
+			if !reflect.DeepEqual(results, test.results) {
+				tt.Fatalf("expected %#v but got %#v instead", test.results, results)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package gomal
+
+import (
+	"reflect"
+	"time"
+)
+
+// timeValue returns the time.Time held by value, following pointers.
+func timeValue(value reflect.Value) (time.Time, bool) {
+	value = indirect(value)
+	if !value.IsValid() || value.Type() != timeType {
+		return time.Time{}, false
+	}
+	return value.Interface().(time.Time), true
+}
+
+// now returns the current time from the option's clock, or time.Now.
+func now(option []ValidatorOption) time.Time {
+	if len(option) > 0 && option[0].Now != nil {
+		return option[0].Now()
+	}
+	return time.Now()
+}
+
+// Before checks that a time.Time is strictly before limit. Like the other time
+// rules, it skips values that are not a time.Time or a pointer to one.
+func (validator Validator) Before(limit time.Time, option ...ValidatorOption) Validator {
+	if validator.stop {
+		return validator
+	}
+
+	if value, ok := timeValue(validator.reflectValue); ok && !value.Before(limit) {
+		validator.addViolation("before", map[string]any{"limit": limit}, "", option)
+	}
+	return validator
+}
+
+// After checks that a time.Time is strictly after limit.
+func (validator Validator) After(limit time.Time, option ...ValidatorOption) Validator {
+	if validator.stop {
+		return validator
+	}
+
+	if value, ok := timeValue(validator.reflectValue); ok && !value.After(limit) {
+		validator.addViolation("after", map[string]any{"limit": limit}, "", option)
+	}
+	return validator
+}
+
+// BetweenTimes checks min <= value <= max for a time.Time.
+func (validator Validator) BetweenTimes(min, max time.Time, option ...ValidatorOption) Validator {
+	if validator.stop {
+		return validator
+	}
+
+	if value, ok := timeValue(validator.reflectValue); ok && (value.Before(min) || value.After(max)) {
+		validator.addViolation("betweentimes", map[string]any{"min": min, "max": max}, "", option)
+	}
+	return validator
+}
+
+// Within checks that a time.Time is at most duration away from now, in the
+// past or in the future. The option's Now replaces the clock, e.g. in tests.
+func (validator Validator) Within(duration time.Duration, option ...ValidatorOption) Validator {
+	if validator.stop {
+		return validator
+	}
+
+	if value, ok := timeValue(validator.reflectValue); ok {
+		difference := value.Sub(now(option))
+		if difference < -duration || difference > duration {
+			validator.addViolation("within", map[string]any{"duration": duration}, "", option)
+		}
+	}
+	return validator
+}
+
+// NotInPast checks that a time.Time is now or later, see Within for the clock.
+func (validator Validator) NotInPast(option ...ValidatorOption) Validator {
+	if validator.stop {
+		return validator
+	}
+
+	if value, ok := timeValue(validator.reflectValue); ok && value.Before(now(option)) {
+		validator.addViolation("notinpast", nil, "", option)
+	}
+	return validator
+}
+
+// NotInFuture checks that a time.Time is now or earlier, see Within for the
+// clock.
+func (validator Validator) NotInFuture(option ...ValidatorOption) Validator {
+	if validator.stop {
+		return validator
+	}
+
+	if value, ok := timeValue(validator.reflectValue); ok && value.After(now(option)) {
+		validator.addViolation("notinfuture", nil, "", option)
+	}
+	return validator
+}
+
+// MinAge checks that a birthdate is at least years ago, so a person born on
+// 29 February turns a year older on 1 March of common years. See Within for
+// the clock.
+func (validator Validator) MinAge(years int, option ...ValidatorOption) Validator {
+	if validator.stop {
+		return validator
+	}
+
+	if value, ok := timeValue(validator.reflectValue); ok && value.AddDate(years, 0, 0).After(now(option)) {
+		validator.addViolation("minage", map[string]any{"years": years}, "", option)
+	}
+	return validator
+}
+
+// Weekday checks that a time.Time falls on one of days, in its own location.
+func (validator Validator) Weekday(days []time.Weekday, option ...ValidatorOption) Validator {
+	if validator.stop {
+		return validator
+	}
+
+	if value, ok := timeValue(validator.reflectValue); ok {
+		for _, day := range days {
+			if value.Weekday() == day {
+				return validator
+			}
+		}
+		allowed := make([]any, len(days))
+		for i, day := range days {
+			allowed[i] = day
+		}
+		validator.addViolation("weekday", map[string]any{"days": allowed}, "", option)
+	}
+	return validator
+}
@@ -0,0 +1,110 @@
+package gomal_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ItsMalma/gomal"
+)
+
+func TestTimeRules(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	clock := gomal.ValidatorOption{Now: func() time.Time { return now }}
+	checkIn := time.Date(2024, 5, 3, 14, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		validator gomal.Validator
+		results   []gomal.ValidationResult
+	}{
+		{
+			name:      "before",
+			validator: gomal.If("check_in", checkIn).Before(checkIn),
+			results:   []gomal.ValidationResult{{Name: "check_in", Messages: []string{"check_in must be before 2024-05-03 14:00 UTC."}}},
+		},
+		{
+			name:      "after",
+			validator: gomal.If("check_in", &checkIn).After(now),
+			results:   []gomal.ValidationResult{},
+		},
+		{
+			name:      "between times",
+			validator: gomal.If("check_in", checkIn).BetweenTimes(now, now.AddDate(0, 0, 1)),
+			results:   []gomal.ValidationResult{{Name: "check_in", Messages: []string{"check_in must be between 2024-05-01 09:00 UTC and 2024-05-02 09:00 UTC."}}},
+		},
+		{
+			name:      "within",
+			validator: gomal.If("check_in", checkIn).Within(24*time.Hour, clock),
+			results:   []gomal.ValidationResult{{Name: "check_in", Messages: []string{"check_in must be within 1 day of now."}}},
+		},
+		{
+			name:      "not in past",
+			validator: gomal.If("check_in", now.Add(-time.Minute)).NotInPast(clock),
+			results:   []gomal.ValidationResult{{Name: "check_in", Messages: []string{"check_in must not be in the past."}}},
+		},
+		{
+			name:      "not in future",
+			validator: gomal.If("birthdate", now).NotInFuture(clock),
+			results:   []gomal.ValidationResult{},
+		},
+		{
+			name:      "min age on birthday",
+			validator: gomal.If("birthdate", time.Date(2006, 5, 1, 0, 0, 0, 0, time.UTC)).MinAge(18, clock),
+			results:   []gomal.ValidationResult{},
+		},
+		{
+			name:      "min age a day early",
+			validator: gomal.If("birthdate", time.Date(2006, 5, 2, 0, 0, 0, 0, time.UTC)).MinAge(18, clock),
+			results:   []gomal.ValidationResult{{Name: "birthdate", Messages: []string{"birthdate must be at least 18 years ago."}}},
+		},
+		{
+			name:      "weekday in own location",
+			validator: gomal.If("check_in", time.Date(2024, 5, 3, 23, 0, 0, 0, time.UTC).In(jakarta)).Weekday([]time.Weekday{time.Friday}),
+			results:   []gomal.ValidationResult{{Name: "check_in", Messages: []string{"check_in must fall on Friday."}}},
+		},
+		{
+			name:      "not a time",
+			validator: gomal.If("check_in", "2024-05-03").NotInPast(clock),
+			results:   []gomal.ValidationResult{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			results := gomal.Validate(test.validator)
+			if !reflect.DeepEqual(results, test.results) {
+				tt.Fatalf("expected %#v but got %#v instead", test.results, results)
+			}
+		})
+	}
+}
+
+func TestTimeMessageLocation(t *testing.T) {
+	catalog := gomal.English()
+	catalog.Location = time.FixedZone("WIB", 7*60*60)
+
+	limit := time.Date(2024, 5, 1, 2, 0, 0, 0, time.UTC)
+	expected := []gomal.ValidationResult{{Name: "check_in", Messages: []string{"check_in must be after 2024-05-01 09:00 WIB."}}}
+	results := gomal.ValidateWith(catalog, gomal.If("check_in", limit).After(limit))
+	if !reflect.DeepEqual(results, expected) {
+		t.Fatalf("expected %#v but got %#v instead", expected, results)
+	}
+}
+
+func TestTimeMessageIndonesian(t *testing.T) {
+	clock := gomal.ValidatorOption{Now: func() time.Time { return time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC) }}
+	checkIn := time.Date(2024, 5, 4, 12, 0, 0, 0, time.UTC)
+
+	expected := []gomal.ValidationResult{{Name: "check_in", Messages: []string{
+		"check_in harus dalam rentang 1 hari 12 jam dari sekarang.",
+		"check_in harus jatuh pada hari Senin, Jumat.",
+	}}}
+	results := gomal.ValidateWith(gomal.Indonesian(), gomal.If("check_in", checkIn).
+		Within(36*time.Hour, clock).
+		Weekday([]time.Weekday{time.Monday, time.Friday}))
+	if !reflect.DeepEqual(results, expected) {
+		t.Fatalf("expected %#v but got %#v instead", expected, results)
+	}
+}
@@ -0,0 +1,138 @@
+package gomal
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// setValue replaces the value checked by the following rules.
+func (validator *Validator) setValue(value any) {
+	validator.value = value
+	validator.reflectValue = reflect.ValueOf(value)
+	validator.valueType = reflect.TypeOf(value)
+	validator.transformed = true
+}
+
+// transformString replaces a string value, keeping its named type, with what
+// transform returns. A string behind a non-nil pointer, as in optional *string
+// fields, is replaced by the transformed string; the variable it points to is
+// left alone, see Into. Values of other kinds are left alone.
+func (validator Validator) transformString(transform func(value string) string) Validator {
+	if validator.stop {
+		return validator
+	}
+
+	value := validator.reflectValue
+	for value.Kind() == reflect.Pointer && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.String {
+		return validator
+	}
+	validator.setValue(reflect.ValueOf(transform(value.String())).Convert(value.Type()).Interface())
+	return validator
+}
+
+// Trim removes the leading and trailing white space of a string for the
+// following rules, see Value.
+func (validator Validator) Trim() Validator {
+	return validator.transformString(strings.TrimSpace)
+}
+
+// Lower maps a string to lower case, e.g. for an email address.
+func (validator Validator) Lower() Validator {
+	return validator.transformString(strings.ToLower)
+}
+
+// NormalizeSpace trims a string and collapses each run of white space inside it
+// into a single space.
+func (validator Validator) NormalizeSpace() Validator {
+	return validator.transformString(func(value string) string {
+		return strings.Join(strings.Fields(value), " ")
+	})
+}
+
+// StripControl removes control and format characters from a string, such as
+// NUL and the invisible zero width space and joiner, keeping tabs and line
+// breaks.
+func (validator Validator) StripControl() Validator {
+	return validator.transformString(func(value string) string {
+		return strings.Map(func(r rune) rune {
+			if r == '\t' || r == '\n' || r == '\r' {
+				return r
+			}
+			if unicode.Is(unicode.Cc, r) || unicode.Is(unicode.Cf, r) {
+				return -1
+			}
+			return r
+		}, value)
+	})
+}
+
+// Transform replaces the value with what transform returns for the following
+// rules.
+func (validator Validator) Transform(transform func(value any) any) Validator {
+	if validator.stop {
+		return validator
+	}
+
+	validator.setValue(transform(validator.value))
+	return validator
+}
+
+// Value returns the value checked by the validator, as changed by its
+// transformers, e.g. to save the cleaned input once it is valid.
+func (validator Validator) Value() any {
+	return validator.value
+}
+
+// Into stores the current value into the variable pointer points to, such as a
+// struct field:
+//
+//	gomal.If("email", req.Email).Trim().Lower().Into(&req.Email).Email()
+//
+// The value must be assignable to the variable, or to what it points to.
+func (validator Validator) Into(pointer any) Validator {
+	if validator.stop {
+		return validator
+	}
+
+	target := reflect.ValueOf(pointer)
+	if target.Kind() != reflect.Pointer || target.IsNil() {
+		panic(fmt.Sprintf("gomal: Into expects a non-nil pointer but got %T", pointer))
+	}
+	if !assign(target.Elem(), validator.value) {
+		panic(fmt.Sprintf("gomal: cannot store %T into %T", validator.value, pointer))
+	}
+	return validator
+}
+
+// assign sets target, or the variable it points to, to value. It reports false
+// when the types do not match.
+func assign(target reflect.Value, value any) bool {
+	source := reflect.ValueOf(value)
+	if !source.IsValid() {
+		switch target.Kind() {
+		case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice:
+			if target.CanSet() {
+				target.SetZero()
+				return true
+			}
+		}
+		return false
+	}
+
+	for !source.Type().AssignableTo(target.Type()) {
+		if target.Kind() != reflect.Pointer || target.IsNil() {
+			return false
+		}
+		target = target.Elem()
+	}
+	if !target.CanSet() {
+		return false
+	}
+	target.Set(source)
+	return true
+}
@@ -0,0 +1,167 @@
+package gomal_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ItsMalma/gomal"
+)
+
+func TestTransformers(t *testing.T) {
+	tests := []struct {
+		name      string
+		validator gomal.Validator
+		value     any
+		results   []gomal.ValidationResult
+	}{
+		{
+			name:      "trim and lower before email",
+			validator: gomal.If("email", "  Malma@Example.COM ").Trim().Lower().NotEmpty().Email(),
+			value:     "malma@example.com",
+			results:   []gomal.ValidationResult{},
+		},
+		{
+			name:      "blank after trim",
+			validator: gomal.If("name", " \t ").Trim().MinLength(1),
+			value:     "",
+			results:   []gomal.ValidationResult{{Name: "name", Messages: []string{"The length of name must be at least 1 characters. You entered 0 characters."}}},
+		},
+		{
+			name:      "normalize space",
+			validator: gomal.If("name", "  Ahmad \n  Malma  ").NormalizeSpace(),
+			value:     "Ahmad Malma",
+			results:   []gomal.ValidationResult{},
+		},
+		{
+			name:      "strip control",
+			validator: gomal.If("username", "mal\u200bma\x00\n").StripControl(),
+			value:     "malma\n",
+			results:   []gomal.ValidationResult{},
+		},
+		{
+			name:      "named type is kept",
+			validator: gomal.If("status", status(" Draft ")).Trim().Lower().OneOf(),
+			value:     status("draft"),
+			results:   []gomal.ValidationResult{},
+		},
+		{
+			name:      "custom",
+			validator: gomal.If("tags", "go, rust").Transform(func(v any) any { return strings.Split(v.(string), ", ") }).MaxItems(1),
+			value:     []string{"go", "rust"},
+			results:   []gomal.ValidationResult{{Name: "tags", Messages: []string{"tags must contain 1 items or fewer. It contains 2 items."}}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			if value := test.validator.Value(); !reflect.DeepEqual(value, test.value) {
+				tt.Fatalf("expected %#v but got %#v instead", test.value, value)
+			}
+			results := gomal.Validate(test.validator)
+			if !reflect.DeepEqual(results, test.results) {
+				tt.Fatalf("expected %#v but got %#v instead", test.results, results)
+			}
+		})
+	}
+}
+
+func TestInto(t *testing.T) {
+	var request struct {
+		Email    string
+		Nickname *string
+	}
+	request.Email = " Malma@Example.com"
+	nickname := " malma "
+	request.Nickname = &nickname
+
+	gomal.Validate(
+		gomal.If("email", request.Email).Trim().Lower().Into(&request.Email).Email(),
+		gomal.If("nickname", request.Nickname).Unwrap().Trim().Into(&request.Nickname),
+	)
+	if request.Email != "malma@example.com" {
+		t.Fatalf("expected %#v but got %#v instead", "malma@example.com", request.Email)
+	}
+	if nickname != "malma" {
+		t.Fatalf("expected %#v but got %#v instead", "malma", nickname)
+	}
+}
+
+func TestValidateStructTransformers(t *testing.T) {
+	type signUp struct {
+		Email string `json:"email" gomal:"trim,lower,email"`
+		Name  string `json:"name" gomal:"normalizespace,notempty"`
+	}
+
+	value := signUp{Email: " Malma@Example.com ", Name: "  Ahmad   Malma "}
+	if results := gomal.ValidateStruct(value); !reflect.DeepEqual(results, []gomal.ValidationResult{}) {
+		t.Fatalf("expected no results but got %#v instead", results)
+	}
+	if value.Email != " Malma@Example.com " {
+		t.Fatalf("expected a struct passed by value to be left alone but got %#v", value.Email)
+	}
+
+	gomal.ValidateStruct(&value)
+	expected := signUp{Email: "malma@example.com", Name: "Ahmad Malma"}
+	if !reflect.DeepEqual(value, expected) {
+		t.Fatalf("expected %#v but got %#v instead", expected, value)
+	}
+}
+
+func TestValidateStructTransformersPointer(t *testing.T) {
+	type profile struct {
+		Name     *string `json:"name" gomal:"trim,unwrap,minlength=2"`
+		Nickname *string `json:"nickname" gomal:"trim,lower,unwrap,minlength=2"`
+		Bio      *string `json:"bio" gomal:"trim"`
+	}
+
+	name, nickname := "  a  ", " MALMA "
+	value := profile{Name: &name, Nickname: &nickname}
+	expected := []gomal.ValidationResult{{Name: "name", Messages: []string{"The length of name must be at least 2 characters. You entered 1 characters."}}}
+	if results := gomal.ValidateStruct(&value); !reflect.DeepEqual(results, expected) {
+		t.Fatalf("expected %#v but got %#v instead", expected, results)
+	}
+	if name != "a" || nickname != "malma" || value.Bio != nil {
+		t.Fatalf("expected the strings to be trimmed through their pointers but got %#v, %#v and %#v", name, nickname, value.Bio)
+	}
+}
+
+func TestTransformPointerUnchanged(t *testing.T) {
+	type profile struct {
+		Name *string `json:"name" gomal:"trim"`
+	}
+
+	name := "  malma  "
+	tests := []struct {
+		name     string
+		validate func() any
+		value    any
+	}{
+		{
+			name:     "if",
+			validate: func() any { return gomal.If("name", &name).Trim().Value() },
+			value:    "malma",
+		},
+		{
+			name:     "not",
+			validate: func() any { return gomal.Validate(gomal.If("name", &name).Not(gomal.Rules.Trim())) },
+			value:    []gomal.ValidationResult{{Name: "name", Messages: []string{"name must not satisfy the trim rule."}}},
+		},
+		{
+			name:     "struct by value",
+			validate: func() any { return gomal.ValidateStruct(profile{Name: &name}) },
+			value:    []gomal.ValidationResult{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			if value := test.validate(); !reflect.DeepEqual(value, test.value) {
+				tt.Fatalf("expected %#v but got %#v instead", test.value, value)
+			}
+			if name != "  malma  " {
+				tt.Fatalf("expected %#v but got %#v instead", "  malma  ", name)
+			}
+		})
+	}
+}
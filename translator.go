@@ -0,0 +1,397 @@
+package gomal
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Translator renders the message of a violation recorded by a built-in rule.
+type Translator interface {
+	Translate(violation Violation) string
+}
+
+// Catalog is a Translator backed by message templates keyed by rule code.
+// Templates reference the violation with placeholders: {field} is the
+// translated field name and every other placeholder, such as {min}, {max} or
+// {actual}, is the rule parameter of that name. Parameters are formatted with
+// %v, except lists of values ([]any) which are joined with commas, times which
+// are converted to Location, when set, and formatted with TimeLayout, and days
+// and durations which are written with Weekdays and DurationUnits. The
+// {otherField} and {otherFields} parameters of the cross-field rules name other
+// fields and are translated like {field}. The {violations} of the combinators
+// are rendered with the catalog and joined with semicolons.
+//
+// Fields maps field names to display names. A field is looked up by its full
+// path ("order.items[3].sku"), then without indexes ("order.items.sku"), then
+// by its last segment ("sku"). Rules missing from Messages fall back to the
+// English catalog, and custom rules missing from both to the "valid" template.
+type Catalog struct {
+	Messages map[string]string
+	Fields   map[string]string
+	// Location is the time zone times are shown in, such as the user's. Times
+	// keep their own location when it is nil.
+	Location *time.Location
+	// TimeLayout formats times, DefaultTimeLayout when empty.
+	TimeLayout string
+	// Weekdays names the days of the week from Sunday to Saturday. Days keep
+	// their English names when it is empty.
+	Weekdays []string
+	// DurationUnits names the units durations are written in, keyed by "day",
+	// "hour", "minute" and "second" and by their plurals "days", "hours",
+	// "minutes" and "seconds". Missing units keep their English names.
+	DurationUnits map[string]string
+}
+
+// DefaultTimeLayout is the layout of the times in messages, e.g.
+// "2024-05-01 08:30 WIB".
+const DefaultTimeLayout = "2006-01-02 15:04 MST"
+
+var englishMessages = map[string]string{
+	"notnil":             "{field} must not be empty.",
+	"notempty":           "{field} should not be empty.",
+	"notequal":           "{field} should not be equal to {other}.",
+	"equal":              "{field} should be equal to {other}.",
+	"length":             "{field} must be between {min} and {max} characters. You entered {actual} characters",
+	"bytelength":         "{field} must be between {min} and {max} bytes. You entered {actual} bytes.",
+	"maxlength":          "The length of {field} must be {max} characters or fewer. You entered {actual} characters.",
+	"minlength":          "The length of {field} must be at least {min} characters. You entered {actual} characters.",
+	"lessthan":           "{field} must be less than {limit}.",
+	"lessthanorequal":    "{field} must be less than or equal to {limit}.",
+	"greaterthan":        "{field} must be greater than {limit}.",
+	"greaterthanorequal": "{field} must be greater than or equal to {limit}.",
+	"regexp":             "{field} is not in the correct format",
+	"email":              "{field} is not a valid email address",
+	"strictemail":        "{field} is not a valid email address",
+	"empty":              "{field} must be empty",
+	"nil":                "{field} must be empty.",
+	"between":            "{field} must be between {min} and {max}.",
+	"oneof":              "{field} must be one of {values}.",
+	"notoneof":           "{field} must not be one of {values}.",
+	"valid":              "{field} is not a valid value.",
+	"type":               "{field} must be of type {type}.",
+	"required":           "{field} is required.",
+	"format":             "{field} must be a valid {format}.",
+	"equalfield":         "{field} must be equal to {otherField}.",
+	"notequalfield":      "{field} must not be equal to {otherField}.",
+	"greaterthanfield":   "{field} must be greater than {otherField}.",
+	"lessthanfield":      "{field} must be less than {otherField}.",
+	"requiredif":         "{field} is required when {otherField} is {values}.",
+	"requiredwith":       "{field} is required when {otherFields} is present.",
+	"url":                "{field} must be a valid URL.",
+	"uuid":               "{field} must be a valid UUID.",
+	"ip":                 "{field} must be a valid IP address.",
+	"ipv4":               "{field} must be a valid IPv4 address.",
+	"ipv6":               "{field} must be a valid IPv6 address.",
+	"cidr":               "{field} must be a valid CIDR block.",
+	"hostname":           "{field} must be a valid hostname.",
+	"fqdn":               "{field} must be a fully qualified domain name.",
+	"macaddress":         "{field} must be a valid MAC address.",
+	"e164phone":          "{field} must be a phone number in the E.164 format, such as +14155552671.",
+	"iso8601date":        "{field} must be a date in the YYYY-MM-DD format.",
+	"rfc3339":            "{field} must be a date and time in the RFC 3339 format.",
+	"base64":             "{field} must be valid Base64.",
+	"hex":                "{field} must be a hexadecimal string.",
+	"json":               "{field} must be valid JSON.",
+	"semver":             "{field} must be a semantic version, such as 1.2.3.",
+	"slug":               "{field} must contain only lowercase letters, digits and hyphens.",
+	"before":             "{field} must be before {limit}.",
+	"after":              "{field} must be after {limit}.",
+	"betweentimes":       "{field} must be between {min} and {max}.",
+	"within":             "{field} must be within {duration} of now.",
+	"notinpast":          "{field} must not be in the past.",
+	"notinfuture":        "{field} must not be in the future.",
+	"minage":             "{field} must be at least {years} years ago.",
+	"weekday":            "{field} must fall on {days}.",
+	"minitems":           "{field} must contain at least {min} items. It contains {actual} items.",
+	"maxitems":           "{field} must contain {max} items or fewer. It contains {actual} items.",
+	"unique":             "{field} must not contain duplicates of {duplicate}.",
+	"contains":           "{field} must contain {value}.",
+	"subset":             "{field} may only contain {values}.",
+	"positive":           "{field} must be positive.",
+	"negative":           "{field} must be negative.",
+	"nonzero":            "{field} must not be zero.",
+	"finite":             "{field} must be a finite number.",
+	"multipleof":         "{field} must be a multiple of {step}.",
+	"precision":          "{field} must have at most {scale} decimal places.",
+	"int":                "{field} must be a whole number.",
+	"float":              "{field} must be a number.",
+	"bool":               "{field} must be true or false.",
+	"duration":           "{field} must be a duration, such as 1h30m.",
+	"time":               "{field} must be a time in the {layout} format.",
+	"anyof":              "{field} must satisfy at least one of the following: {violations}.",
+	"allof":              "{field} must satisfy all of the following: {violations}.",
+	"not":                "{field} must not satisfy the {rule} rule.",
+	"exactlyoneof":       "{field} must satisfy exactly one of the {rules} rules, but satisfies {passed}.",
+}
+
+var indonesianMessages = map[string]string{
+	"notnil":             "{field} tidak boleh kosong.",
+	"notempty":           "{field} tidak boleh kosong.",
+	"notequal":           "{field} tidak boleh sama dengan {other}.",
+	"equal":              "{field} harus sama dengan {other}.",
+	"length":             "{field} harus terdiri dari {min} sampai {max} karakter. Anda memasukkan {actual} karakter.",
+	"bytelength":         "{field} harus berukuran {min} sampai {max} byte. Anda memasukkan {actual} byte.",
+	"maxlength":          "Panjang {field} maksimal {max} karakter. Anda memasukkan {actual} karakter.",
+	"minlength":          "Panjang {field} minimal {min} karakter. Anda memasukkan {actual} karakter.",
+	"lessthan":           "{field} harus kurang dari {limit}.",
+	"lessthanorequal":    "{field} harus kurang dari atau sama dengan {limit}.",
+	"greaterthan":        "{field} harus lebih dari {limit}.",
+	"greaterthanorequal": "{field} harus lebih dari atau sama dengan {limit}.",
+	"regexp":             "Format {field} tidak valid.",
+	"email":              "{field} bukan alamat email yang valid.",
+	"strictemail":        "{field} bukan alamat email yang valid.",
+	"empty":              "{field} harus kosong.",
+	"nil":                "{field} harus kosong.",
+	"between":            "{field} harus di antara {min} dan {max}.",
+	"oneof":              "{field} harus salah satu dari {values}.",
+	"notoneof":           "{field} tidak boleh salah satu dari {values}.",
+	"valid":              "{field} bukan nilai yang valid.",
+	"type":               "{field} harus bertipe {type}.",
+	"required":           "{field} wajib diisi.",
+	"format":             "{field} harus berupa {format} yang valid.",
+	"equalfield":         "{field} harus sama dengan {otherField}.",
+	"notequalfield":      "{field} tidak boleh sama dengan {otherField}.",
+	"greaterthanfield":   "{field} harus lebih dari {otherField}.",
+	"lessthanfield":      "{field} harus kurang dari {otherField}.",
+	"requiredif":         "{field} wajib diisi jika {otherField} bernilai {values}.",
+	"requiredwith":       "{field} wajib diisi jika {otherFields} diisi.",
+	"url":                "{field} harus berupa URL yang valid.",
+	"uuid":               "{field} harus berupa UUID yang valid.",
+	"ip":                 "{field} harus berupa alamat IP yang valid.",
+	"ipv4":               "{field} harus berupa alamat IPv4 yang valid.",
+	"ipv6":               "{field} harus berupa alamat IPv6 yang valid.",
+	"cidr":               "{field} harus berupa blok CIDR yang valid.",
+	"hostname":           "{field} harus berupa nama host yang valid.",
+	"fqdn":               "{field} harus berupa nama domain lengkap (FQDN).",
+	"macaddress":         "{field} harus berupa alamat MAC yang valid.",
+	"e164phone":          "{field} harus berupa nomor telepon dalam format E.164, misalnya +6281234567890.",
+	"iso8601date":        "{field} harus berupa tanggal dengan format YYYY-MM-DD.",
+	"rfc3339":            "{field} harus berupa tanggal dan waktu dengan format RFC 3339.",
+	"base64":             "{field} harus berupa Base64 yang valid.",
+	"hex":                "{field} harus berupa string heksadesimal.",
+	"json":               "{field} harus berupa JSON yang valid.",
+	"semver":             "{field} harus berupa versi semantik, misalnya 1.2.3.",
+	"slug":               "{field} hanya boleh berisi huruf kecil, angka, dan tanda hubung.",
+	"before":             "{field} harus sebelum {limit}.",
+	"after":              "{field} harus setelah {limit}.",
+	"betweentimes":       "{field} harus di antara {min} dan {max}.",
+	"within":             "{field} harus dalam rentang {duration} dari sekarang.",
+	"notinpast":          "{field} tidak boleh di masa lalu.",
+	"notinfuture":        "{field} tidak boleh di masa depan.",
+	"minage":             "{field} harus paling sedikit {years} tahun yang lalu.",
+	"weekday":            "{field} harus jatuh pada hari {days}.",
+	"minitems":           "{field} harus berisi minimal {min} item. Saat ini berisi {actual} item.",
+	"maxitems":           "{field} harus berisi maksimal {max} item. Saat ini berisi {actual} item.",
+	"unique":             "{field} tidak boleh berisi duplikat {duplicate}.",
+	"contains":           "{field} harus berisi {value}.",
+	"subset":             "{field} hanya boleh berisi {values}.",
+	"positive":           "{field} harus bernilai positif.",
+	"negative":           "{field} harus bernilai negatif.",
+	"nonzero":            "{field} tidak boleh nol.",
+	"finite":             "{field} harus berupa bilangan hingga.",
+	"multipleof":         "{field} harus kelipatan {step}.",
+	"precision":          "{field} maksimal memiliki {scale} angka desimal.",
+	"int":                "{field} harus berupa bilangan bulat.",
+	"float":              "{field} harus berupa angka.",
+	"bool":               "{field} harus bernilai true atau false.",
+	"duration":           "{field} harus berupa durasi, misalnya 1h30m.",
+	"time":               "{field} harus berupa waktu dengan format {layout}.",
+	"anyof":              "{field} harus memenuhi minimal salah satu dari berikut: {violations}.",
+	"allof":              "{field} harus memenuhi semua yang berikut: {violations}.",
+	"not":                "{field} tidak boleh memenuhi aturan {rule}.",
+	"exactlyoneof":       "{field} harus memenuhi tepat satu dari aturan {rules}, tetapi memenuhi {passed}.",
+}
+
+var (
+	defaultTranslator Translator = English()
+
+	placeholderRegexp = regexp.MustCompile(`\{([a-zA-Z0-9_]+)\}`)
+	pathIndexRegexp   = regexp.MustCompile(`\[[^\]]*\]`)
+)
+
+var indonesianWeekdays = []string{"Minggu", "Senin", "Selasa", "Rabu", "Kamis", "Jumat", "Sabtu"}
+
+var indonesianDurationUnits = map[string]string{
+	"day":     "hari",
+	"days":    "hari",
+	"hour":    "jam",
+	"hours":   "jam",
+	"minute":  "menit",
+	"minutes": "menit",
+	"second":  "detik",
+	"seconds": "detik",
+}
+
+// English returns a new copy of the bundled English ("en") catalog, which
+// produces the default messages.
+func English() *Catalog {
+	return newCatalog(englishMessages, nil, nil)
+}
+
+// Indonesian returns a new copy of the bundled Indonesian ("id") catalog.
+func Indonesian() *Catalog {
+	return newCatalog(indonesianMessages, indonesianWeekdays, indonesianDurationUnits)
+}
+
+func newCatalog(messages map[string]string, weekdays []string, durationUnits map[string]string) *Catalog {
+	catalog := &Catalog{
+		Messages:      make(map[string]string, len(messages)),
+		Fields:        map[string]string{},
+		Weekdays:      append([]string(nil), weekdays...),
+		DurationUnits: map[string]string{},
+	}
+	for rule, message := range messages {
+		catalog.Messages[rule] = message
+	}
+	for unit, name := range durationUnits {
+		catalog.DurationUnits[unit] = name
+	}
+	return catalog
+}
+
+func (catalog *Catalog) Translate(violation Violation) string {
+	template, ok := catalog.Messages[violation.Rule]
+	if !ok {
+		if template, ok = englishMessages[violation.Rule]; !ok {
+			if violation.Message != "" {
+				return violation.Message
+			}
+			// A custom rule without a template, see NewRule.
+			if template, ok = catalog.Messages["valid"]; !ok {
+				template = englishMessages["valid"]
+			}
+		}
+	}
+
+	return placeholderRegexp.ReplaceAllStringFunc(template, func(placeholder string) string {
+		name := placeholder[1 : len(placeholder)-1]
+		if name == "field" {
+			return catalog.fieldName(violation.Field)
+		}
+		param, ok := violation.Params[name]
+		if !ok {
+			return placeholder
+		}
+		switch name {
+		case "otherField":
+			return catalog.fieldName(fmt.Sprint(param))
+		case "otherFields":
+			if fields, ok := param.([]any); ok {
+				names := make([]any, len(fields))
+				for i, field := range fields {
+					names[i] = catalog.fieldName(fmt.Sprint(field))
+				}
+				param = names
+			}
+		}
+		return catalog.formatParam(param)
+	})
+}
+
+func (catalog *Catalog) fieldName(field string) string {
+	if name, ok := catalog.Fields[field]; ok {
+		return name
+	}
+	withoutIndexes := pathIndexRegexp.ReplaceAllString(field, "")
+	if name, ok := catalog.Fields[withoutIndexes]; ok {
+		return name
+	}
+	if i := strings.LastIndex(withoutIndexes, "."); i >= 0 {
+		if name, ok := catalog.Fields[withoutIndexes[i+1:]]; ok {
+			return name
+		}
+	}
+	return field
+}
+
+func (catalog *Catalog) formatParam(param any) string {
+	switch param := param.(type) {
+	case []any:
+		formatted := make([]string, len(param))
+		for i, value := range param {
+			formatted[i] = catalog.formatParam(value)
+		}
+		return strings.Join(formatted, ", ")
+	case []Violation:
+		messages := make([]string, len(param))
+		for i, violation := range param {
+			messages[i] = strings.TrimSuffix(render(catalog, violation).Message, ".")
+		}
+		return strings.Join(messages, "; ")
+	case time.Time:
+		if catalog.Location != nil {
+			param = param.In(catalog.Location)
+		}
+		layout := catalog.TimeLayout
+		if layout == "" {
+			layout = DefaultTimeLayout
+		}
+		return param.Format(layout)
+	case time.Weekday:
+		if int(param) < len(catalog.Weekdays) {
+			return catalog.Weekdays[param]
+		}
+	case time.Duration:
+		return catalog.formatDuration(param)
+	}
+	return fmt.Sprint(param)
+}
+
+// formatDuration writes duration in days, hours, minutes and seconds, e.g.
+// "1 day 12 hours", leaving out the units that are zero. Durations with
+// fractions of a second are written like "1.5s".
+func (catalog *Catalog) formatDuration(duration time.Duration) string {
+	if duration%time.Second != 0 {
+		return duration.String()
+	}
+	sign := ""
+	if duration < 0 {
+		sign, duration = "-", -duration
+	}
+
+	parts := []string{}
+	for _, unit := range []struct {
+		name   string
+		length time.Duration
+	}{
+		{"day", 24 * time.Hour},
+		{"hour", time.Hour},
+		{"minute", time.Minute},
+		{"second", time.Second},
+	} {
+		count := duration / unit.length
+		duration -= count * unit.length
+		if count == 0 && (len(parts) > 0 || unit.name != "second") {
+			continue
+		}
+		name := unit.name
+		if count != 1 {
+			name += "s"
+		}
+		if translated, ok := catalog.DurationUnits[name]; ok {
+			name = translated
+		}
+		parts = append(parts, fmt.Sprintf("%d %v", count, name))
+	}
+	return sign + strings.Join(parts, " ")
+}
+
+type translatorKey struct{}
+
+// WithTranslator returns a copy of ctx carrying translator, e.g. one picked from
+// the request's Accept-Language header.
+func WithTranslator(ctx context.Context, translator Translator) context.Context {
+	return context.WithValue(ctx, translatorKey{}, translator)
+}
+
+// TranslatorFrom returns the translator carried by ctx, or the English catalog
+// when there is none.
+func TranslatorFrom(ctx context.Context) Translator {
+	if translator, ok := ctx.Value(translatorKey{}).(Translator); ok {
+		return translator
+	}
+	return defaultTranslator
+}
@@ -0,0 +1,86 @@
+package gomal_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/ItsMalma/gomal"
+)
+
+func TestValidateWith(t *testing.T) {
+	indonesian := gomal.Indonesian()
+	indonesian.Fields["email"] = "Surel"
+	indonesian.Fields["items.sku"] = "Kode barang"
+
+	japanese := &gomal.Catalog{
+		Messages: map[string]string{"notempty": "{field}を入力してください。"},
+		Fields:   map[string]string{"name": "名前"},
+	}
+
+	tests := []struct {
+		name       string
+		translator gomal.Translator
+		validators []gomal.Validator
+		results    []gomal.ValidationResult
+	}{
+		{
+			name:       "indonesian",
+			translator: indonesian,
+			validators: []gomal.Validator{
+				gomal.If("email", "").NotEmpty(),
+				gomal.If("username", "ab").Length(3, 16),
+				gomal.If("items", []string{""}).Dive(func(item gomal.Validator) gomal.Validator {
+					return item.NotEmpty()
+				}),
+			},
+			results: []gomal.ValidationResult{
+				{Name: "email", Messages: []string{"Surel tidak boleh kosong."}},
+				{Name: "username", Messages: []string{"username harus terdiri dari 3 sampai 16 karakter. Anda memasukkan 2 karakter."}},
+				{Name: "items[0]", Messages: []string{"items[0] tidak boleh kosong."}},
+			},
+		},
+		{
+			name:       "custom catalog falls back to english",
+			translator: japanese,
+			validators: []gomal.Validator{
+				gomal.If("name", "").NotEmpty().MinLength(2),
+			},
+			results: []gomal.ValidationResult{
+				{Name: "name", Messages: []string{"名前を入力してください。", "The length of 名前 must be at least 2 characters. You entered 0 characters."}},
+			},
+		},
+		{
+			name:       "custom messages are kept",
+			translator: indonesian,
+			validators: []gomal.Validator{
+				gomal.If("age", 1).GreaterThan(17, gomal.ValidatorOption{ErrorMessage: "too young"}),
+				gomal.If("code", "x").Is(func() (bool, string) { return false, "code is taken" }),
+			},
+			results: []gomal.ValidationResult{
+				{Name: "age", Messages: []string{"too young"}},
+				{Name: "code", Messages: []string{"code is taken"}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			results := gomal.ValidateWith(test.translator, test.validators...)
+			if !reflect.DeepEqual(results, test.results) {
+				tt.Fatalf("expected %#v but got %#v instead", test.results, results)
+			}
+		})
+	}
+}
+
+func TestTranslatorFrom(t *testing.T) {
+	if translator := gomal.TranslatorFrom(context.Background()); !reflect.DeepEqual(translator, gomal.English()) {
+		t.Fatalf("expected the english catalog but got %#v instead", translator)
+	}
+
+	indonesian := gomal.Indonesian()
+	if translator := gomal.TranslatorFrom(gomal.WithTranslator(context.Background(), indonesian)); translator != indonesian {
+		t.Fatalf("expected the indonesian catalog but got %#v instead", translator)
+	}
+}
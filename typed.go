@@ -0,0 +1,87 @@
+package gomal
+
+// Ordered is satisfied by every type that supports the < <= >= > operators.
+type Ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 |
+		~string
+}
+
+// TypedValidator is a Validator for a value of a static type, so its rules are
+// checked by the compiler instead of by reflection. The embedded Validator is
+// what Validate accepts, e.g.
+//
+//	gomal.Validate(gomal.Of("age", age).Between(17, 65).Validator)
+type TypedValidator[T Ordered] struct {
+	Validator
+
+	typedValue T
+}
+
+// Min checks min <= value. NaN fails.
+func (validator TypedValidator[T]) Min(min T, option ...ValidatorOption) TypedValidator[T] {
+	if validator.stop {
+		return validator
+	}
+
+	if isNaN(validator.reflectValue) || validator.typedValue < min {
+		validator.addViolation("greaterthanorequal", map[string]any{"limit": min}, "", option)
+	}
+	return validator
+}
+
+// Max checks value <= max. NaN fails.
+func (validator TypedValidator[T]) Max(max T, option ...ValidatorOption) TypedValidator[T] {
+	if validator.stop {
+		return validator
+	}
+
+	if isNaN(validator.reflectValue) || validator.typedValue > max {
+		validator.addViolation("lessthanorequal", map[string]any{"limit": max}, "", option)
+	}
+	return validator
+}
+
+// Between checks min <= value <= max. NaN fails.
+func (validator TypedValidator[T]) Between(min, max T, option ...ValidatorOption) TypedValidator[T] {
+	if validator.stop {
+		return validator
+	}
+
+	if isNaN(validator.reflectValue) || validator.typedValue < min || validator.typedValue > max {
+		validator.addViolation("between", map[string]any{"min": min, "max": max}, "", option)
+	}
+	return validator
+}
+
+func (validator TypedValidator[T]) OneOf(values ...T) TypedValidator[T] {
+	if validator.stop {
+		return validator
+	}
+
+	for _, value := range values {
+		if validator.typedValue == value {
+			return validator
+		}
+	}
+
+	allowed := make([]any, len(values))
+	for i, value := range values {
+		allowed[i] = value
+	}
+	validator.addViolation("oneof", map[string]any{"values": allowed}, "", nil)
+	return validator
+}
+
+func (validator TypedValidator[T]) When(condition bool) TypedValidator[T] {
+	validator.Validator = validator.Validator.When(condition)
+	return validator
+}
+
+func Of[T Ordered](name string, value T) TypedValidator[T] {
+	return TypedValidator[T]{
+		Validator:  If(name, value),
+		typedValue: value,
+	}
+}
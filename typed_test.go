@@ -0,0 +1,64 @@
+package gomal_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/ItsMalma/gomal"
+)
+
+func TestOf(t *testing.T) {
+	type status string
+
+	tests := []struct {
+		name      string
+		validator gomal.Validator
+		results   []gomal.ValidationResult
+	}{
+		{
+			name:      "success",
+			validator: gomal.Of("x", 5).Min(1).Max(10).Between(5, 5).OneOf(1, 5).Validator,
+			results:   []gomal.ValidationResult{},
+		},
+		{
+			name:      "failed min",
+			validator: gomal.Of("x", 0).Min(1).Validator,
+			results:   []gomal.ValidationResult{{Name: "x", Messages: []string{"x must be greater than or equal to 1."}}},
+		},
+		{
+			name:      "failed max",
+			validator: gomal.Of("x", 1.5).Max(1).Validator,
+			results:   []gomal.ValidationResult{{Name: "x", Messages: []string{"x must be less than or equal to 1."}}},
+		},
+		{
+			name:      "failed between",
+			validator: gomal.Of("x", uint8(11)).Between(1, 10).Validator,
+			results:   []gomal.ValidationResult{{Name: "x", Messages: []string{"x must be between 1 and 10."}}},
+		},
+		{
+			name:      "failed one of",
+			validator: gomal.Of("x", status("deleted")).OneOf("draft", "published").Validator,
+			results:   []gomal.ValidationResult{{Name: "x", Messages: []string{"x must be one of draft, published."}}},
+		},
+		{
+			name:      "nan",
+			validator: gomal.Of("x", math.NaN()).Min(0).Max(10).Between(0, 10).Validator,
+			results:   []gomal.ValidationResult{{Name: "x", Messages: []string{"x must be greater than or equal to 0.", "x must be less than or equal to 10.", "x must be between 0 and 10."}}},
+		},
+		{
+			name:      "skipped by when",
+			validator: gomal.Of("x", 0).When(false).Min(1).Validator,
+			results:   []gomal.ValidationResult{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			results := gomal.Validate(test.validator)
+			if !reflect.DeepEqual(results, test.results) {
+				tt.Fatalf("expected %#v but got %#v instead", test.results, results)
+			}
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package gomal
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Validatable is implemented by types with invariants spanning several fields,
+// e.g. a discount given either as a percentage or as an amount:
+//
+//	func (d Discount) Validate() []gomal.ValidationResult {
+//		if d.Percent != 0 && d.Amount != 0 {
+//			return []gomal.ValidationResult{{Name: "amount", Messages: []string{"amount must be empty when percent is set."}}}
+//		}
+//		return nil
+//	}
+//
+// Validate is called when a validator holding such a value is validated,
+// whether the value was passed to If, found in a struct by ValidateStruct or
+// reached through Field and Dive, unless its rules are skipped with When.
+// Result names are relative to the value, like the paths ValidateStruct
+// reports, and an empty name stands for the value itself; their messages are
+// merged into the results under the value's path.
+//
+// Validate may pass its receiver to ValidateStruct to check the rules of its
+// fields as well. While it runs, ValidateStruct does not call it again, and
+// when ValidateStruct already checks the fields of the value, as it does for
+// nested structs, the inner call reports nothing so no message is repeated.
+type Validatable interface {
+	Validate() []ValidationResult
+}
+
+// ValidatableContext is Validatable for invariants needing a context, such as
+// a database lookup. ValidateContext receives the context of ValidateContext,
+// or context.Background.
+type ValidatableContext interface {
+	ValidateContext(ctx context.Context) []ValidationResult
+}
+
+// BeforeValidator is implemented by types preparing their value before it is
+// validated, e.g. to trim or fill in defaults. ValidateStruct calls
+// BeforeValidate on the structs, fields and elements it can change in place,
+// which are the ones reached through a pointer, so the struct must be passed by
+// pointer. The rules of the value then see its changes. If does not call it.
+type BeforeValidator interface {
+	BeforeValidate()
+}
+
+// AfterValidator is implemented by types observing the outcome of their
+// validation. AfterValidate receives the results of the value and its nested
+// fields and elements, which are empty when it is valid.
+type AfterValidator interface {
+	AfterValidate(results []ValidationResult)
+}
+
+var (
+	hookTypes = []reflect.Type{
+		reflect.TypeFor[Validatable](),
+		reflect.TypeFor[ValidatableContext](),
+		reflect.TypeFor[AfterValidator](),
+	}
+
+	hooksCache sync.Map
+
+	// invariantsRunning holds, per goroutine, the values whose Validate or
+	// ValidateContext method is running, see checkingInvariants.
+	invariantsRunning = struct {
+		sync.Mutex
+		values map[uint64][]runningInvariants
+	}{values: map[uint64][]runningInvariants{}}
+)
+
+type runningInvariants struct {
+	value any
+	// fields is set when the validator of value checks its fields too.
+	fields bool
+}
+
+// hasHooks reports whether values of valueType, or pointers to them, implement
+// one of Validatable, ValidatableContext and AfterValidator.
+func hasHooks(valueType reflect.Type) bool {
+	if found, ok := hooksCache.Load(valueType); ok {
+		return found.(bool)
+	}
+
+	receiverType := valueType
+	if valueType.Kind() != reflect.Pointer {
+		receiverType = reflect.PointerTo(valueType)
+	}
+	found := false
+	for _, hookType := range hookTypes {
+		if receiverType.Implements(hookType) {
+			found = true
+			break
+		}
+	}
+	hooksCache.Store(valueType, found)
+	return found
+}
+
+// receiver returns what to call the methods of Validatable, ValidatableContext
+// and AfterValidator on: the value, or a pointer to a copy of it for methods
+// with a pointer receiver. It returns nil when the value has none of them, or
+// when its rules are skipped, see When.
+func (validator Validator) receiver() any {
+	if validator.stop || !validator.invariants || isNil(validator.reflectValue) || !hasHooks(validator.valueType) {
+		return nil
+	}
+	if running, _ := checkingInvariants(indirect(validator.reflectValue).Interface()); running {
+		return nil
+	}
+	if validator.reflectValue.Kind() == reflect.Pointer {
+		return validator.value
+	}
+	pointer := reflect.New(validator.valueType)
+	pointer.Elem().Set(validator.reflectValue)
+	return pointer.Interface()
+}
+
+// beforeValidate calls BeforeValidate on value in place, which is a non-nil
+// pointer or an addressable value. Pointers in visiting are skipped since they
+// were prepared already.
+func beforeValidate(value reflect.Value, visiting map[visit]bool) {
+	if value.Kind() == reflect.Interface {
+		value = value.Elem()
+	}
+	switch {
+	case value.Kind() == reflect.Pointer:
+		if value.IsNil() || visiting[visit{value.Pointer(), value.Type()}] {
+			return
+		}
+	case value.CanAddr():
+		value = value.Addr()
+	default:
+		return
+	}
+	if hook, ok := value.Interface().(BeforeValidator); ok {
+		hook.BeforeValidate()
+	}
+}
+
+// goroutineID returns the ID of the calling goroutine, read from the header of
+// its stack trace, "goroutine 18 [running]:".
+func goroutineID() uint64 {
+	var buf [64]byte
+	trace := buf[:runtime.Stack(buf[:], false)]
+	trace = bytes.TrimPrefix(trace, []byte("goroutine "))
+	id, _ := strconv.ParseUint(string(trace[:bytes.IndexByte(trace, ' ')]), 10, 64)
+	return id
+}
+
+// checkingInvariants reports whether the Validate or ValidateContext method of
+// a value equal to value is running on the calling goroutine, which happens
+// when it passes its receiver to ValidateStruct, and whether the fields of that
+// value are being checked as well.
+func checkingInvariants(value any) (running bool, fields bool) {
+	invariantsRunning.Lock()
+	defer invariantsRunning.Unlock()
+	if len(invariantsRunning.values) == 0 {
+		return false, false
+	}
+	for _, running := range invariantsRunning.values[goroutineID()] {
+		if reflect.DeepEqual(running.value, value) {
+			return true, running.fields
+		}
+	}
+	return false, false
+}
+
+// runInvariants calls check while the invariants of validator's value are being
+// checked on the calling goroutine, see checkingInvariants.
+func (validator Validator) runInvariants(check func()) {
+	id := goroutineID()
+	invariantsRunning.Lock()
+	invariantsRunning.values[id] = append(invariantsRunning.values[id], runningInvariants{
+		value:  indirect(validator.reflectValue).Interface(),
+		fields: validator.fields,
+	})
+	invariantsRunning.Unlock()
+
+	defer func() {
+		invariantsRunning.Lock()
+		defer invariantsRunning.Unlock()
+		if running := invariantsRunning.values[id]; len(running) > 1 {
+			invariantsRunning.values[id] = running[:len(running)-1]
+		} else {
+			delete(invariantsRunning.values, id)
+		}
+	}()
+	check()
+}
+
+// validateReceiver merges the results of the value's Validate and
+// ValidateContext methods into validator.
+func (validator Validator) validateReceiver(ctx context.Context, receiver any) (Validator, error) {
+	results := []ValidationResult{}
+	if receiver, ok := receiver.(Validatable); ok {
+		validator.runInvariants(func() { results = append(results, receiver.Validate()...) })
+	}
+	if receiver, ok := receiver.(ValidatableContext); ok {
+		if err := ctx.Err(); err != nil {
+			return validator, err
+		}
+		validator.runInvariants(func() { results = append(results, receiver.ValidateContext(ctx)...) })
+	}
+
+	for _, result := range results {
+		path := validator.name
+		if result.Name != "" {
+			path = fieldPath(validator.name, result.Name)
+			if strings.HasPrefix(result.Name, "[") {
+				path = validator.name + result.Name
+			}
+		}
+		violations := make([]Violation, len(result.Messages))
+		for i, message := range result.Messages {
+			violations[i] = Violation{Field: path, Rule: "is", Value: validator.value, Message: message}
+		}
+		validator = validator.record(path, violations)
+	}
+	return validator, nil
+}
+
+// record adds violations to the validator named path, which is validator or
+// one of its nested fields and elements. It adds a child when there is none.
+func (validator Validator) record(path string, violations []Violation) Validator {
+	if validator.name == path {
+		validator.violations = append(validator.violations[:len(validator.violations):len(validator.violations)], violations...)
+		return validator
+	}
+
+	for i, child := range validator.children {
+		if child.name == path || strings.HasPrefix(path, child.name+".") || strings.HasPrefix(path, child.name+"[") {
+			children := make([]Validator, len(validator.children))
+			copy(children, validator.children)
+			children[i] = child.record(path, violations)
+			validator.children = children
+			return validator
+		}
+	}
+	child := If(path, nil)
+	child.violations = violations
+	validator.children = append(validator.children[:len(validator.children):len(validator.children)], child)
+	return validator
+}
@@ -0,0 +1,303 @@
+package gomal_test
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ItsMalma/gomal"
+)
+
+type discount struct {
+	Percent int `json:"percent" gomal:"between=0|100"`
+	Amount  int `json:"amount"`
+}
+
+func (d discount) Validate() []gomal.ValidationResult {
+	if d.Percent != 0 && d.Amount != 0 {
+		return []gomal.ValidationResult{{Name: "amount", Messages: []string{"amount must be empty when percent is set."}}}
+	}
+	return nil
+}
+
+type checkout struct {
+	Code      string     `json:"code" gomal:"notempty"`
+	Discount  discount   `json:"discount"`
+	Discounts []discount `json:"discounts"`
+}
+
+func TestValidatable(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   checkout
+		results []gomal.ValidationResult
+	}{
+		{
+			name:    "success",
+			value:   checkout{Code: "A1", Discount: discount{Percent: 10}, Discounts: []discount{{Amount: 5}}},
+			results: []gomal.ValidationResult{},
+		},
+		{
+			name:  "failed nested",
+			value: checkout{Code: "A1", Discount: discount{Percent: 150, Amount: 5}},
+			results: []gomal.ValidationResult{
+				{Name: "discount.percent", Messages: []string{"discount.percent must be between 0 and 100."}},
+				{Name: "discount.amount", Messages: []string{"amount must be empty when percent is set."}},
+			},
+		},
+		{
+			name:  "failed element",
+			value: checkout{Code: "A1", Discounts: []discount{{Percent: 10}, {Percent: 10, Amount: 5}}},
+			results: []gomal.ValidationResult{
+				{Name: "discounts[1].amount", Messages: []string{"amount must be empty when percent is set."}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			results := gomal.ValidateStruct(test.value)
+			if !reflect.DeepEqual(results, test.results) {
+				tt.Fatalf("expected %#v but got %#v instead", test.results, results)
+			}
+		})
+	}
+}
+
+func TestValidatableIf(t *testing.T) {
+	expected := []gomal.ValidationResult{
+		{Name: "discount", Messages: []string{"discount must not be empty."}},
+		{Name: "discount.amount", Messages: []string{"amount must be empty when percent is set."}},
+	}
+	results := gomal.Validate(
+		gomal.If("discount", discount{Percent: 10, Amount: 5}).Is(func() (bool, string) { return false, "discount must not be empty." }),
+		gomal.If("other", discount{Amount: 5}),
+		gomal.If("none", (*discount)(nil)),
+	)
+	if !reflect.DeepEqual(results, expected) {
+		t.Fatalf("expected %#v but got %#v instead", expected, results)
+	}
+}
+
+type period struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+func (p period) Validate() []gomal.ValidationResult {
+	if p.End < p.Start {
+		return []gomal.ValidationResult{{Messages: []string{"period must not end before it starts."}}}
+	}
+	return nil
+}
+
+func TestValidatableRoot(t *testing.T) {
+	expected := []gomal.ValidationResult{{Name: "", Messages: []string{"period must not end before it starts."}}}
+	results := gomal.ValidateStruct(&period{Start: 5, End: 1})
+	if !reflect.DeepEqual(results, expected) {
+		t.Fatalf("expected %#v but got %#v instead", expected, results)
+	}
+
+	violations := gomal.StructViolations(period{Start: 5, End: 1})
+	if len(violations) != 1 || violations[0].Rule != "is" || violations[0].Value != (period{Start: 5, End: 1}) {
+		t.Fatalf("expected an is violation for the period but got %#v instead", violations)
+	}
+}
+
+type tenantKey struct{}
+
+type reservation struct {
+	Room string `json:"room"`
+}
+
+func (r reservation) ValidateContext(ctx context.Context) []gomal.ValidationResult {
+	if taken, _ := ctx.Value(tenantKey{}).(string); taken == r.Room {
+		return []gomal.ValidationResult{{Name: "room", Messages: []string{"room is already booked."}}}
+	}
+	return nil
+}
+
+func TestValidatableContext(t *testing.T) {
+	ctx := context.WithValue(context.Background(), tenantKey{}, "101")
+
+	expected := []gomal.ValidationResult{{Name: "reservations[0].room", Messages: []string{"room is already booked."}}}
+	results, err := gomal.ValidateContext(ctx, gomal.If("reservations", []reservation{{Room: "101"}, {Room: "102"}}).Dive(func(item gomal.Validator) gomal.Validator {
+		return item
+	}))
+	if err != nil {
+		t.Fatalf("expected no error but got %v instead", err)
+	}
+	if !reflect.DeepEqual(results, expected) {
+		t.Fatalf("expected %#v but got %#v instead", expected, results)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := gomal.ValidateContext(cancelled, gomal.If("reservation", reservation{Room: "101"})); err != context.Canceled {
+		t.Fatalf("expected %v but got %v instead", context.Canceled, err)
+	}
+}
+
+func TestValidateStructContext(t *testing.T) {
+	type booking struct {
+		Reservation reservation `json:"reservation"`
+	}
+
+	ctx := context.WithValue(context.Background(), tenantKey{}, "101")
+	expected := []gomal.ValidationResult{{Name: "reservation.room", Messages: []string{"room is already booked."}}}
+	results, err := gomal.ValidateStructContext(ctx, booking{Reservation: reservation{Room: "101"}})
+	if err != nil {
+		t.Fatalf("expected no error but got %v instead", err)
+	}
+	if !reflect.DeepEqual(results, expected) {
+		t.Fatalf("expected %#v but got %#v instead", expected, results)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := gomal.ValidateStructContext(cancelled, booking{}); err != context.Canceled {
+		t.Fatalf("expected %v but got %v instead", context.Canceled, err)
+	}
+}
+
+type coupon struct {
+	Code string `json:"code" gomal:"notempty"`
+
+	results []gomal.ValidationResult
+}
+
+func (c *coupon) BeforeValidate() {
+	c.Code = strings.TrimSpace(c.Code)
+}
+
+func (c *coupon) AfterValidate(results []gomal.ValidationResult) {
+	c.results = results
+}
+
+func TestValidateHooks(t *testing.T) {
+	type order struct {
+		Coupon coupon `json:"coupon"`
+	}
+
+	value := order{Coupon: coupon{Code: "  SAVE10 "}}
+	if results := gomal.ValidateStruct(&value); len(results) != 0 {
+		t.Fatalf("expected no results but got %#v instead", results)
+	}
+	if value.Coupon.Code != "SAVE10" {
+		t.Fatalf("expected %#v but got %#v instead", "SAVE10", value.Coupon.Code)
+	}
+
+	byValue := order{Coupon: coupon{Code: "  SAVE10 "}}
+	gomal.ValidateStruct(byValue)
+	if byValue.Coupon.Code != "  SAVE10 " {
+		t.Fatalf("expected %#v but got %#v instead", "  SAVE10 ", byValue.Coupon.Code)
+	}
+
+	root := &coupon{Code: "   "}
+	expected := []gomal.ValidationResult{{Name: "code", Messages: []string{"code tidak boleh kosong."}}}
+	results := gomal.ValidateStructWith(gomal.Indonesian(), root)
+	if !reflect.DeepEqual(results, expected) {
+		t.Fatalf("expected %#v but got %#v instead", expected, results)
+	}
+	if !reflect.DeepEqual(root.results, expected) {
+		t.Fatalf("expected %#v but got %#v instead", expected, root.results)
+	}
+}
+
+func TestValidateHooksSkipped(t *testing.T) {
+	value := &coupon{Code: "  SAVE10 "}
+	validator := gomal.If("coupon", value)
+	if value.Code != "  SAVE10 " {
+		t.Fatalf("expected If not to call BeforeValidate but got %#v", value.Code)
+	}
+
+	results := gomal.Validate(
+		validator,
+		gomal.If("discount", discount{Percent: 10, Amount: 5}).When(false),
+	)
+	if len(results) != 0 {
+		t.Fatalf("expected no results but got %#v instead", results)
+	}
+	if value.Code != "  SAVE10 " || value.results == nil {
+		t.Fatalf("expected only AfterValidate to be called but got %#v", value)
+	}
+}
+
+type member struct {
+	Name string `json:"name" gomal:"notempty"`
+	Age  int    `json:"age"`
+}
+
+func (m member) Validate() []gomal.ValidationResult {
+	results := gomal.ValidateStruct(m)
+	if m.Age < 0 {
+		results = append(results, gomal.ValidationResult{Name: "age", Messages: []string{"age must not be negative."}})
+	}
+	return results
+}
+
+func TestValidatableSelf(t *testing.T) {
+	type team struct {
+		Lead member `json:"lead"`
+	}
+
+	tests := []struct {
+		name    string
+		results func() []gomal.ValidationResult
+		want    []gomal.ValidationResult
+	}{
+		{
+			name:    "root",
+			results: func() []gomal.ValidationResult { return gomal.ValidateStruct(member{Age: -1}) },
+			want: []gomal.ValidationResult{
+				{Name: "name", Messages: []string{"name should not be empty."}},
+				{Name: "age", Messages: []string{"age must not be negative."}},
+			},
+		},
+		{
+			name:    "nested",
+			results: func() []gomal.ValidationResult { return gomal.ValidateStruct(&team{Lead: member{Age: -1}}) },
+			want: []gomal.ValidationResult{
+				{Name: "lead.name", Messages: []string{"lead.name should not be empty."}},
+				{Name: "lead.age", Messages: []string{"age must not be negative."}},
+			},
+		},
+		{
+			name:    "if",
+			results: func() []gomal.ValidationResult { return gomal.Validate(gomal.If("lead", member{Age: -1})) },
+			want: []gomal.ValidationResult{
+				{Name: "lead.name", Messages: []string{"name should not be empty."}},
+				{Name: "lead.age", Messages: []string{"age must not be negative."}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			if results := test.results(); !reflect.DeepEqual(results, test.want) {
+				tt.Fatalf("expected %#v but got %#v instead", test.want, results)
+			}
+		})
+	}
+}
+
+func TestValidatableSelfConcurrent(t *testing.T) {
+	expected := []gomal.ValidationResult{{Name: "age", Messages: []string{"age must not be negative."}}}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if results := gomal.ValidateStruct(member{Name: "malma", Age: -1}); !reflect.DeepEqual(results, expected) {
+					t.Errorf("expected %#v but got %#v instead", expected, results)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
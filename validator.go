@@ -1,13 +1,25 @@
 package gomal
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/mail"
 	"reflect"
-	"regexp"
 	"unicode"
 )
 
+type deferredRule struct {
+	// value is the validator's value when the rule was added.
+	value    any
+	callback func(ctx context.Context, value any) error
+	option   []ValidatorOption
+
+	// check, when set instead of callback, returns the violations to record,
+	// see combine.
+	check func(ctx context.Context) ([]Violation, error)
+}
+
 type Validator struct {
 	name string
 
@@ -15,7 +27,32 @@ type Validator struct {
 	reflectValue reflect.Value
 	valueType    reflect.Type
 
-	errorMessages []string
+	violations []Violation
+
+	// children holds the validators of nested fields and elements, see Field and Dive.
+	children []Validator
+
+	// deferred holds the context-aware rules, run when the validator is validated.
+	deferred []deferredRule
+
+	// siblings looks up the other fields of the struct or schema being
+	// validated, see EqualField.
+	siblings func(name string) (sibling, bool)
+
+	// visiting holds the pointers ValidateStruct is descending into, so cyclic
+	// values are not descended into forever, see descend.
+	visiting map[visit]bool
+
+	// fields is set when children holds the validators of the fields of the
+	// struct value, see ValidateStruct.
+	fields bool
+
+	// transformed is set once a transformer such as Trim changed the value.
+	transformed bool
+
+	// invariants is set when resolve checks the invariants of the value, see
+	// Validatable. It is unset on the copies made by probe.
+	invariants bool
 
 	stop bool
 }
@@ -27,18 +64,29 @@ func (validator Validator) getOption(option ...ValidatorOption) (ValidatorOption
 	return option[0], true
 }
 
+// addViolation records that rule failed. An empty message is rendered later
+// from the translator's template for rule. The option's error message, when
+// given, replaces both.
+func (validator *Validator) addViolation(rule string, params map[string]any, message string, option []ValidatorOption) {
+	if opt, useOpt := validator.getOption(option...); useOpt {
+		message = opt.ErrorMessage
+	}
+	validator.violations = append(validator.violations, Violation{
+		Field:   validator.name,
+		Rule:    rule,
+		Params:  params,
+		Value:   validator.value,
+		Message: message,
+	})
+}
+
 func (validator Validator) NotNil(option ...ValidatorOption) Validator {
 	if validator.stop {
 		return validator
 	}
 
-	if validator.value == nil {
-		opt, useOpt := validator.getOption(option...)
-		if useOpt {
-			validator.errorMessages = append(validator.errorMessages, opt.ErrorMessage)
-		} else {
-			validator.errorMessages = append(validator.errorMessages, fmt.Sprintf("%v must not be empty.", validator.name))
-		}
+	if isNil(validator.reflectValue) {
+		validator.addViolation("notnil", nil, "", option)
 	}
 	return validator
 }
@@ -48,60 +96,12 @@ func (validator Validator) NotEmpty(option ...ValidatorOption) Validator {
 		return validator
 	}
 
-	errorMessage := ""
-	if validator.value == nil {
-		errorMessage = fmt.Sprintf("%v should not be empty.", validator.name)
+	if validator.valueType != nil && validator.valueType.Kind() == reflect.Pointer && validator.reflectValue.Elem().Kind() != reflect.Array {
 		return validator
-	} else {
-		switch validator.valueType.Kind() {
-		case reflect.Array, reflect.Chan, reflect.Map, reflect.Pointer, reflect.Slice:
-			if validator.valueType.Kind() == reflect.Pointer && validator.reflectValue.Elem().Kind() != reflect.Array {
-				return validator
-			}
-			if validator.reflectValue.Len() < 1 {
-				errorMessage = fmt.Sprintf("%v should not be empty.", validator.name)
-			}
-		case reflect.Bool:
-			if validator.value == false {
-				errorMessage = fmt.Sprintf("%v should not be empty.", validator.name)
-			}
-		case reflect.Complex64, reflect.Complex128:
-			if validator.value == 0+0i {
-				errorMessage = fmt.Sprintf("%v should not be empty.", validator.name)
-			}
-		case reflect.Float32, reflect.Float64:
-			if validator.value == 0.0 {
-				errorMessage = fmt.Sprintf("%v should not be empty.", validator.name)
-			}
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-			if validator.value == 0 {
-				errorMessage = fmt.Sprintf("%v should not be empty.", validator.name)
-			}
-		case reflect.String:
-			valueAsStr := validator.reflectValue.String()
-			if len(valueAsStr) < 1 {
-				errorMessage = fmt.Sprintf("%v should not be empty.", validator.name)
-			} else {
-				allWhitespace := true
-				for _, ch := range valueAsStr {
-					if !unicode.IsSpace(ch) {
-						allWhitespace = false
-					}
-				}
-				if allWhitespace {
-					errorMessage = fmt.Sprintf("%v should not be empty.", validator.name)
-				}
-			}
-		}
 	}
 
-	if errorMessage != "" {
-		opt, useOpt := validator.getOption(option...)
-		if useOpt {
-			validator.errorMessages = append(validator.errorMessages, opt.ErrorMessage)
-		} else {
-			validator.errorMessages = append(validator.errorMessages, errorMessage)
-		}
+	if isEmpty(validator.reflectValue) {
+		validator.addViolation("notempty", nil, "", option)
 	}
 
 	return validator
@@ -113,12 +113,7 @@ func (validator Validator) NotEqual(another any, option ...ValidatorOption) Vali
 	}
 
 	if reflect.DeepEqual(validator.value, another) {
-		opt, useOpt := validator.getOption(option...)
-		if useOpt {
-			validator.errorMessages = append(validator.errorMessages, opt.ErrorMessage)
-		} else {
-			validator.errorMessages = append(validator.errorMessages, fmt.Sprintf("%v should not be equal to %v.", validator.name, another))
-		}
+		validator.addViolation("notequal", map[string]any{"other": another}, "", option)
 	}
 	return validator
 }
@@ -129,239 +124,109 @@ func (validator Validator) Equal(another any, option ...ValidatorOption) Validat
 	}
 
 	if !reflect.DeepEqual(validator.value, another) {
-		opt, useOpt := validator.getOption(option...)
-		if useOpt {
-			validator.errorMessages = append(validator.errorMessages, opt.ErrorMessage)
-		} else {
-			validator.errorMessages = append(validator.errorMessages, fmt.Sprintf("%v should be equal to %v.", validator.name, another))
-		}
+		validator.addViolation("equal", map[string]any{"other": another}, "", option)
 	}
 	return validator
 }
 
-// Only work for string
+// Length checks that a string is between min and max characters long. It
+// counts runes, or user-perceived characters when the option's Unit is
+// Graphemes. Other values are skipped.
 func (validator Validator) Length(min, max int, option ...ValidatorOption) Validator {
 	if validator.stop {
 		return validator
 	}
 
 	if validator.valueType.Kind() == reflect.String {
-		valueLength := validator.reflectValue.Len()
+		valueLength := stringLength(validator.reflectValue.String(), option)
 		if valueLength < min || valueLength > max {
-			opt, useOpt := validator.getOption(option...)
-			if useOpt {
-				validator.errorMessages = append(validator.errorMessages, opt.ErrorMessage)
-			} else {
-				validator.errorMessages = append(validator.errorMessages, fmt.Sprintf(
-					"%v must be between %v and %v characters. You entered %v characters",
-					validator.name, min, max, valueLength,
-				))
-			}
+			validator.addViolation("length", map[string]any{"min": min, "max": max, "actual": valueLength}, "", option)
 		}
 	}
 	return validator
 }
 
-// Only work for string
+// MaxLength checks that a string is at most max characters long, counted as
+// in Length.
 func (validator Validator) MaxLength(max int, option ...ValidatorOption) Validator {
 	if validator.stop {
 		return validator
 	}
 
 	if validator.valueType.Kind() == reflect.String {
-		valueLength := validator.reflectValue.Len()
+		valueLength := stringLength(validator.reflectValue.String(), option)
 		if valueLength > max {
-			opt, useOpt := validator.getOption(option...)
-			if useOpt {
-				validator.errorMessages = append(validator.errorMessages, opt.ErrorMessage)
-			} else {
-				validator.errorMessages = append(validator.errorMessages, fmt.Sprintf(
-					"The length of %v must be %v characters or fewer. You entered %v characters.",
-					validator.name, max, valueLength,
-				))
-			}
+			validator.addViolation("maxlength", map[string]any{"max": max, "actual": valueLength}, "", option)
 		}
 	}
 	return validator
 }
 
-// Only work for string
+// MinLength checks that a string is at least min characters long, counted as
+// in Length.
 func (validator Validator) MinLength(min int, option ...ValidatorOption) Validator {
 	if validator.stop {
 		return validator
 	}
 
 	if validator.valueType.Kind() == reflect.String {
-		valueLength := validator.reflectValue.Len()
+		valueLength := stringLength(validator.reflectValue.String(), option)
 		if valueLength < min {
-			opt, useOpt := validator.getOption(option...)
-			if useOpt {
-				validator.errorMessages = append(validator.errorMessages, opt.ErrorMessage)
-			} else {
-				validator.errorMessages = append(validator.errorMessages, fmt.Sprintf(
-					"The length of %v must be at least %v characters. You entered %v characters.",
-					validator.name, min, valueLength,
-				))
-			}
+			validator.addViolation("minlength", map[string]any{"min": min, "actual": valueLength}, "", option)
 		}
 	}
 	return validator
 }
 
-// Only work for numerical data type (int, uint, and float)
-func (validator Validator) LessThan(another any, option ...ValidatorOption) Validator {
+// ByteLength checks that a string takes between min and max bytes once encoded
+// in UTF-8, e.g. to fit a database column.
+func (validator Validator) ByteLength(min, max int, option ...ValidatorOption) Validator {
 	if validator.stop {
 		return validator
 	}
 
-	errorMessage := ""
-	switch validator.valueType.Kind() {
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		if validator.reflectValue.Int() >= another.(int64) {
-			errorMessage = fmt.Sprintf("%v must be less than %v.", validator.name, another)
-		}
-	case reflect.Float32, reflect.Float64:
-		if validator.reflectValue.Float() >= another.(float64) {
-			errorMessage = fmt.Sprintf("%v must be less than %v.", validator.name, another)
-		}
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		if validator.reflectValue.Uint() >= another.(uint64) {
-			errorMessage = fmt.Sprintf("%v must be less than %v.", validator.name, another)
-		}
-	}
-
-	if errorMessage != "" {
-		opt, useOpt := validator.getOption(option...)
-		if useOpt {
-			validator.errorMessages = append(validator.errorMessages, opt.ErrorMessage)
-		} else {
-			validator.errorMessages = append(validator.errorMessages, errorMessage)
+	if validator.valueType.Kind() == reflect.String {
+		valueLength := validator.reflectValue.Len()
+		if valueLength < min || valueLength > max {
+			validator.addViolation("bytelength", map[string]any{"min": min, "max": max, "actual": valueLength}, "", option)
 		}
 	}
-
 	return validator
 }
 
-// Only work for numerical data type (int, uint, and float)
-func (validator Validator) LessThanOrEqual(another any, option ...ValidatorOption) Validator {
-	if validator.stop {
-		return validator
-	}
-
-	errorMessage := ""
-	switch validator.valueType.Kind() {
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		if validator.reflectValue.Int() > another.(int64) {
-			errorMessage = fmt.Sprintf("%v must be less than or equal to %v.", validator.name, another)
-		}
-	case reflect.Float32, reflect.Float64:
-		if validator.reflectValue.Float() > another.(float64) {
-			errorMessage = fmt.Sprintf("%v must be less than or equal to %v.", validator.name, another)
-		}
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		if validator.reflectValue.Uint() > another.(uint64) {
-			errorMessage = fmt.Sprintf("%v must be less than or equal to %v.", validator.name, another)
-		}
-	}
-
-	if errorMessage != "" {
-		opt, useOpt := validator.getOption(option...)
-		if useOpt {
-			validator.errorMessages = append(validator.errorMessages, opt.ErrorMessage)
-		} else {
-			validator.errorMessages = append(validator.errorMessages, errorMessage)
-		}
-	}
+// Only work for numbers, see compareNumber. NaN fails.
+func (validator Validator) LessThan(another any, option ...ValidatorOption) Validator {
+	return validator.checkNumber("lessthan", map[string]any{"limit": another}, another, func(result int) bool { return result >= 0 }, option)
+}
 
-	return validator
+// Only work for numbers, see compareNumber. NaN fails.
+func (validator Validator) LessThanOrEqual(another any, option ...ValidatorOption) Validator {
+	return validator.checkNumber("lessthanorequal", map[string]any{"limit": another}, another, func(result int) bool { return result > 0 }, option)
 }
 
-// Only work for numerical data type (int, uint, and float)
+// Only work for numbers, see compareNumber. NaN fails.
 func (validator Validator) GreaterThan(another any, option ...ValidatorOption) Validator {
-	if validator.stop {
-		return validator
-	}
-
-	errorMessage := ""
-	switch validator.valueType.Kind() {
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		if validator.reflectValue.Int() <= another.(int64) {
-			errorMessage = fmt.Sprintf("%v must be greater than %v.", validator.name, another)
-		}
-	case reflect.Float32, reflect.Float64:
-		if validator.reflectValue.Float() <= another.(float64) {
-			errorMessage = fmt.Sprintf("%v must be greater than %v.", validator.name, another)
-		}
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		if validator.reflectValue.Uint() <= another.(uint64) {
-			errorMessage = fmt.Sprintf("%v must be greater than %v.", validator.name, another)
-		}
-	}
-
-	if errorMessage != "" {
-		opt, useOpt := validator.getOption(option...)
-		if useOpt {
-			validator.errorMessages = append(validator.errorMessages, opt.ErrorMessage)
-		} else {
-			validator.errorMessages = append(validator.errorMessages, errorMessage)
-		}
-	}
-
-	return validator
+	return validator.checkNumber("greaterthan", map[string]any{"limit": another}, another, func(result int) bool { return result <= 0 }, option)
 }
 
-// Only work for numerical data type (int, uint, and float)
+// Only work for numbers, see compareNumber. NaN fails.
 func (validator Validator) GreaterThanOrEqual(another any, option ...ValidatorOption) Validator {
-	if validator.stop {
-		return validator
-	}
-
-	errorMessage := ""
-	switch validator.valueType.Kind() {
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		if validator.reflectValue.Int() < another.(int64) {
-			errorMessage = fmt.Sprintf("%v must be greater than or equal to %v.", validator.name, another)
-		}
-	case reflect.Float32, reflect.Float64:
-		if validator.reflectValue.Float() < another.(float64) {
-			errorMessage = fmt.Sprintf("%v must be greater than or equal to %v.", validator.name, another)
-		}
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		if validator.reflectValue.Uint() < another.(uint64) {
-			errorMessage = fmt.Sprintf("%v must be greater than or equal to %v.", validator.name, another)
-		}
-	}
-
-	if errorMessage != "" {
-		opt, useOpt := validator.getOption(option...)
-		if useOpt {
-			validator.errorMessages = append(validator.errorMessages, opt.ErrorMessage)
-		} else {
-			validator.errorMessages = append(validator.errorMessages, errorMessage)
-		}
-	}
-
-	return validator
+	return validator.checkNumber("greaterthanorequal", map[string]any{"limit": another}, another, func(result int) bool { return result < 0 }, option)
 }
 
-// Only work for string
-func (validator Validator) RegExp(expr string, option ...ValidatorOption) Validator {
+// RegExp checks that a string matches pattern, a *regexp.Regexp or a string,
+// whose compiled form is cached while it is in use. Strings never match an
+// invalid pattern; declare the rule with Rules.RegExp or a struct tag to catch
+// those before validating.
+func (validator Validator) RegExp(pattern any, option ...ValidatorOption) Validator {
 	if validator.stop {
 		return validator
 	}
 
 	if validator.valueType.Kind() == reflect.String {
-		match, err := regexp.MatchString(expr, validator.reflectValue.String())
-		if err != nil {
-			panic(err)
-		}
-		if !match {
-			opt, useOpt := validator.getOption(option...)
-			if useOpt {
-				validator.errorMessages = append(validator.errorMessages, opt.ErrorMessage)
-			} else {
-				validator.errorMessages = append(validator.errorMessages, fmt.Sprintf("%v is not in the correct format", validator.name))
-			}
+		compiled, err := compileRegexp(pattern)
+		if err != nil || !compiled.MatchString(validator.reflectValue.String()) {
+			validator.addViolation("regexp", map[string]any{"pattern": fmt.Sprint(pattern)}, "", option)
 		}
 	}
 
@@ -376,12 +241,7 @@ func (validator Validator) Email(option ...ValidatorOption) Validator {
 
 	if validator.valueType.Kind() == reflect.String {
 		if _, err := mail.ParseAddress(validator.reflectValue.String()); err != nil {
-			opt, useOpt := validator.getOption(option...)
-			if useOpt {
-				validator.errorMessages = append(validator.errorMessages, opt.ErrorMessage)
-			} else {
-				validator.errorMessages = append(validator.errorMessages, fmt.Sprintf("%v is not a valid email address", validator.name))
-			}
+			validator.addViolation("email", nil, "", option)
 		}
 	}
 
@@ -393,31 +253,31 @@ func (validator Validator) Empty(option ...ValidatorOption) Validator {
 		return validator
 	}
 
-	errorMessage := ""
+	failed := false
 	switch validator.valueType.Kind() {
 	case reflect.Array, reflect.Chan, reflect.Map, reflect.Pointer, reflect.Slice:
 		if validator.valueType.Kind() == reflect.Pointer && validator.reflectValue.Elem().Kind() != reflect.Array {
 			return validator
 		}
 		if validator.reflectValue.Len() > 0 {
-			errorMessage = fmt.Sprintf("%v must be empty", validator.name)
+			failed = true
 		}
 	case reflect.Bool:
 		if validator.value == false {
-			errorMessage = fmt.Sprintf("%v must be empty", validator.name)
+			failed = true
 		}
 	case reflect.Complex64, reflect.Complex128:
 		if validator.value != 0+0i {
-			errorMessage = fmt.Sprintf("%v must be empty", validator.name)
+			failed = true
 		}
 	case reflect.Float32, reflect.Float64, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		if validator.value != 0 {
-			errorMessage = fmt.Sprintf("%v must be empty", validator.name)
+			failed = true
 		}
 	case reflect.String:
 		valueAsStr := validator.reflectValue.String()
 		if len(valueAsStr) > 0 {
-			errorMessage = fmt.Sprintf("%v must be empty", validator.name)
+			failed = true
 		}
 		allWhitespace := true
 		for _, ch := range valueAsStr {
@@ -426,17 +286,12 @@ func (validator Validator) Empty(option ...ValidatorOption) Validator {
 			}
 		}
 		if !allWhitespace {
-			errorMessage = fmt.Sprintf("%v must be empty", validator.name)
+			failed = true
 		}
 	}
 
-	if errorMessage != "" {
-		opt, useOpt := validator.getOption(option...)
-		if useOpt {
-			validator.errorMessages = append(validator.errorMessages, opt.ErrorMessage)
-		} else {
-			validator.errorMessages = append(validator.errorMessages, errorMessage)
-		}
+	if failed {
+		validator.addViolation("empty", nil, "", option)
 	}
 
 	return validator
@@ -447,67 +302,37 @@ func (validator Validator) Nil(option ...ValidatorOption) Validator {
 		return validator
 	}
 
-	if validator.value != nil {
-		opt, useOpt := validator.getOption(option...)
-		if useOpt {
-			validator.errorMessages = append(validator.errorMessages, opt.ErrorMessage)
-		} else {
-			validator.errorMessages = append(validator.errorMessages, fmt.Sprintf("%v must be empty.", validator.name))
-		}
+	if !isNil(validator.reflectValue) {
+		validator.addViolation("nil", nil, "", option)
 	}
 	return validator
 }
 
-// Only work for numerical data type (int, uint, and float)
+// Only work for numbers, see compareNumber. NaN fails.
 func (validator Validator) Between(min, max any, option ...ValidatorOption) Validator {
 	if validator.stop {
 		return validator
 	}
 
-	errorMessage := ""
-	switch validator.valueType.Kind() {
-	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		value := validator.reflectValue.Int()
-		minValue := min.(int64)
-		maxValue := max.(int64)
-		if value > minValue && value < maxValue {
-			errorMessage = fmt.Sprintf("%v must be between %v and %v.", validator.name, minValue, maxValue)
-		}
-	case reflect.Float32, reflect.Float64:
-		value := validator.reflectValue.Float()
-		minValue := min.(float64)
-		maxValue := max.(float64)
-		if value > minValue && value < maxValue {
-			errorMessage = fmt.Sprintf("%v must be between %v and %v.", validator.name, minValue, maxValue)
-		}
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		value := validator.reflectValue.Uint()
-		minValue := min.(uint64)
-		maxValue := max.(uint64)
-		if value > minValue && value < maxValue {
-			errorMessage = fmt.Sprintf("%v must be between %v and %v.", validator.name, minValue, maxValue)
-		}
-	}
-
-	if errorMessage != "" {
-		opt, useOpt := validator.getOption(option...)
-		if useOpt {
-			validator.errorMessages = append(validator.errorMessages, opt.ErrorMessage)
-		} else {
-			validator.errorMessages = append(validator.errorMessages, errorMessage)
-		}
+	minResult, minOk := compareNumber(validator.reflectValue, min)
+	maxResult, maxOk := compareNumber(validator.reflectValue, max)
+	if minOk && maxOk && (minResult < 0 || maxResult > 0 || isNaN(validator.reflectValue)) {
+		validator.addViolation("between", map[string]any{"min": min, "max": max}, "", option)
 	}
 
 	return validator
 }
 
-// Unwrap if value is pointer
+// Unwrap if value is pointer. A nil pointer skips the remaining rules.
 func (validator Validator) Unwrap() Validator {
 	if validator.stop {
 		return validator
 	}
 
 	if validator.reflectValue.Kind() == reflect.Pointer {
+		if validator.reflectValue.IsNil() {
+			return validator.When(false)
+		}
 		validator.value = validator.reflectValue.Elem().Interface()
 		validator.reflectValue = validator.reflectValue.Elem()
 		validator.valueType = validator.reflectValue.Type()
@@ -516,6 +341,63 @@ func (validator Validator) Unwrap() Validator {
 	return validator
 }
 
+// Field validates the exported struct field with the given Go name using
+// callback. The field is reported as "name.field", using its json name when it
+// has one. Pointers are followed and nil pointers are skipped.
+func (validator Validator) Field(name string, callback func(field Validator) Validator) Validator {
+	if validator.stop {
+		return validator
+	}
+
+	structValue := indirect(validator.reflectValue)
+	if !structValue.IsValid() {
+		return validator
+	}
+	if structValue.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("gomal: %v is not a struct", validator.name))
+	}
+	field, ok := structValue.Type().FieldByName(name)
+	if !ok || !field.IsExported() {
+		panic(fmt.Sprintf("gomal: %v has no exported field %v", validator.name, name))
+	}
+	fieldValue, err := structValue.FieldByIndexErr(field.Index)
+	if err != nil {
+		return validator
+	}
+
+	child := If(fieldPath(validator.name, fieldName(field)), fieldValue.Interface())
+	child.siblings = structSiblings(validator.name, structValue)
+	validator.children = append(validator.children, callback(child))
+	return validator
+}
+
+// Dive validates every element of a slice, array or map using callback.
+// Elements are reported as "name[index]", map values as "name[key]" with string
+// keys quoted. Map entries are visited in key order.
+func (validator Validator) Dive(callback func(item Validator) Validator) Validator {
+	if validator.stop {
+		return validator
+	}
+
+	value := indirect(validator.reflectValue)
+	switch value.Kind() {
+	case reflect.Array, reflect.Slice:
+		for i := 0; i < value.Len(); i++ {
+			item := If(indexPath(validator.name, i), value.Index(i).Interface())
+			item.visiting = validator.visiting
+			validator.children = append(validator.children, callback(item))
+		}
+	case reflect.Map:
+		for _, key := range sortedMapKeys(value) {
+			item := If(keyPath(validator.name, key), value.MapIndex(key).Interface())
+			item.visiting = validator.visiting
+			validator.children = append(validator.children, callback(item))
+		}
+	}
+
+	return validator
+}
+
 func (validator Validator) Is(callback func() (bool, string), option ...ValidatorOption) Validator {
 	if validator.stop {
 		return validator
@@ -523,18 +405,88 @@ func (validator Validator) Is(callback func() (bool, string), option ...Validato
 
 	if success, errorMessage := callback(); !success {
 		if errorMessage != "" {
-			opt, useOpt := validator.getOption(option...)
-			if useOpt {
-				validator.errorMessages = append(validator.errorMessages, opt.ErrorMessage)
-			} else {
-				validator.errorMessages = append(validator.errorMessages, errorMessage)
-			}
+			validator.addViolation("is", nil, errorMessage, option)
 		}
 	}
 
 	return validator
 }
 
+// IsCtx adds a rule that needs a context, such as a database lookup. The
+// callback receives the current value and reports a failure by returning an
+// error whose text is used as the message.
+//
+// Unlike the other rules, the callback runs when the validator is validated:
+// ValidateContext passes its context and stops at cancellation, Validate uses
+// context.Background. Its violations are listed after the other rules'.
+func (validator Validator) IsCtx(callback func(ctx context.Context, value any) error, option ...ValidatorOption) Validator {
+	if validator.stop {
+		return validator
+	}
+
+	validator.deferred = append(validator.deferred[:len(validator.deferred):len(validator.deferred)], deferredRule{
+		value:    validator.value,
+		callback: callback,
+		option:   option,
+	})
+	return validator
+}
+
+// resolve runs the deferred rules of validator and its children. It returns
+// the context's error when ctx is done before every rule ran.
+func (validator Validator) resolve(ctx context.Context) (Validator, error) {
+	receiver := validator.receiver()
+	if receiver != nil {
+		var err error
+		if validator, err = validator.validateReceiver(ctx, receiver); err != nil {
+			return validator, err
+		}
+	}
+
+	if len(validator.deferred) > 0 {
+		validator.violations = validator.violations[:len(validator.violations):len(validator.violations)]
+		for _, rule := range validator.deferred {
+			if err := ctx.Err(); err != nil {
+				return validator, err
+			}
+			if rule.check != nil {
+				violations, err := rule.check(ctx)
+				if err != nil {
+					return validator, err
+				}
+				validator.violations = append(validator.violations, violations...)
+				continue
+			}
+			if err := rule.callback(ctx, rule.value); err != nil {
+				if ctxErr := ctx.Err(); ctxErr != nil && errors.Is(err, ctxErr) {
+					return validator, ctxErr
+				}
+				validator.addViolation("is", nil, err.Error(), rule.option)
+				validator.violations[len(validator.violations)-1].Value = rule.value
+			}
+		}
+		validator.deferred = nil
+	}
+
+	if len(validator.children) > 0 {
+		children := make([]Validator, len(validator.children))
+		for i, child := range validator.children {
+			resolved, err := child.resolve(ctx)
+			if err != nil {
+				return validator, err
+			}
+			children[i] = resolved
+		}
+		validator.children = children
+	}
+
+	if hook, ok := receiver.(AfterValidator); ok {
+		hook.AfterValidate(appendResults([]ValidationResult{}, TranslatorFrom(ctx), validator))
+	}
+
+	return validator, nil
+}
+
 func (validator Validator) When(condition bool) Validator {
 	if !condition {
 		validator.stop = true
@@ -542,13 +494,17 @@ func (validator Validator) When(condition bool) Validator {
 	return validator
 }
 
+// If starts validating value under name. The invariants of a value
+// implementing Validatable are checked when it is validated.
 func If(name string, value any) Validator {
 	return Validator{
-		name:          name,
-		value:         value,
-		reflectValue:  reflect.ValueOf(value),
-		valueType:     reflect.TypeOf(value),
-		errorMessages: []string{},
-		stop:          false,
+		name:         name,
+		value:        value,
+		reflectValue: reflect.ValueOf(value),
+		valueType:    reflect.TypeOf(value),
+		violations:   []Violation{},
+		children:     []Validator{},
+		invariants:   true,
+		stop:         false,
 	}
 }
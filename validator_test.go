@@ -380,3 +380,144 @@ func TestUnwrap(t *testing.T) {
 		t.Fatalf("expected empty but got %v instead", results)
 	}
 }
+
+func TestField(t *testing.T) {
+	type item struct {
+		SKU string `json:"sku"`
+	}
+	type order struct {
+		Items []item `json:"items"`
+		Note  *string
+	}
+
+	value := order{Items: []item{{SKU: "A-1"}, {SKU: ""}}}
+	results := gomal.Validate(gomal.If("order", value).
+		Field("Items", func(items gomal.Validator) gomal.Validator {
+			return items.NotEmpty().Dive(func(item gomal.Validator) gomal.Validator {
+				return item.Field("SKU", func(sku gomal.Validator) gomal.Validator {
+					return sku.NotEmpty()
+				})
+			})
+		}).
+		Field("Note", func(note gomal.Validator) gomal.Validator {
+			return note.NotNil()
+		}))
+
+	expected := []gomal.ValidationResult{
+		{Name: "order.items[1].sku", Messages: []string{"order.items[1].sku should not be empty."}},
+		{Name: "order.Note", Messages: []string{"order.Note must not be empty."}},
+	}
+	if !reflect.DeepEqual(results, expected) {
+		t.Fatalf("expected %#v but got %#v instead", expected, results)
+	}
+}
+
+func TestDive(t *testing.T) {
+	tests := []struct {
+		name       string
+		nameField  string
+		valueField any
+		results    []gomal.ValidationResult
+	}{
+		{
+			name:       "success",
+			nameField:  "x",
+			valueField: []string{"a", "b"},
+			results:    []gomal.ValidationResult{},
+		},
+		{
+			name:       "failed (slice)",
+			nameField:  "x",
+			valueField: []string{"a", ""},
+			results:    []gomal.ValidationResult{{Name: "x[1]", Messages: []string{"x[1] should not be empty."}}},
+		},
+		{
+			name:       "failed (map)",
+			nameField:  "headers",
+			valueField: map[string]string{"X-Id": "", "Accept": "", "Host": "example.com"},
+			results: []gomal.ValidationResult{
+				{Name: `headers["Accept"]`, Messages: []string{`headers["Accept"] should not be empty.`}},
+				{Name: `headers["X-Id"]`, Messages: []string{`headers["X-Id"] should not be empty.`}},
+			},
+		},
+		{
+			name:       "failed (map with int key)",
+			nameField:  "x",
+			valueField: map[int]string{2: "", 1: "a"},
+			results:    []gomal.ValidationResult{{Name: "x[2]", Messages: []string{"x[2] should not be empty."}}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			results := gomal.Validate(gomal.If(test.nameField, test.valueField).Dive(func(item gomal.Validator) gomal.Validator {
+				return item.NotEmpty()
+			}))
+			if !reflect.DeepEqual(results, test.results) {
+				tt.Fatalf("expected %#v but got %#v instead", test.results, results)
+			}
+		})
+	}
+}
+
+func TestBetween(t *testing.T) {
+	tests := []struct {
+		name       string
+		nameField  string
+		valueField any
+		min, max   any
+		results    []gomal.ValidationResult
+	}{
+		{
+			name:       "success (int with plain int bounds)",
+			nameField:  "x",
+			valueField: 5,
+			min:        1,
+			max:        5,
+			results:    []gomal.ValidationResult{},
+		},
+		{
+			name:       "success (uint with float bounds)",
+			nameField:  "x",
+			valueField: uint(2),
+			min:        1.5,
+			max:        2.5,
+			results:    []gomal.ValidationResult{},
+		},
+		{
+			name:       "failed (below)",
+			nameField:  "x",
+			valueField: int8(-1),
+			min:        0,
+			max:        10,
+			results:    []gomal.ValidationResult{{Name: "x", Messages: []string{"x must be between 0 and 10."}}},
+		},
+		{
+			name:       "failed (above)",
+			nameField:  "x",
+			valueField: 10.5,
+			min:        0,
+			max:        10,
+			results:    []gomal.ValidationResult{{Name: "x", Messages: []string{"x must be between 0 and 10."}}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(tt *testing.T) {
+			results := gomal.Validate(gomal.If(test.nameField, test.valueField).Between(test.min, test.max))
+			if !reflect.DeepEqual(results, test.results) {
+				tt.Fatalf("expected %#v but got %#v instead", test.results, results)
+			}
+		})
+	}
+}
+
+func TestLessThan(t *testing.T) {
+	if results := gomal.Validate(gomal.If("x", 1).LessThan(2)); !reflect.DeepEqual(results, []gomal.ValidationResult{}) {
+		t.Fatalf("expected empty but got %v instead", results)
+	}
+
+	if results := gomal.Validate(gomal.If("x", uint(3)).LessThan(-1)); !reflect.DeepEqual(results, []gomal.ValidationResult{{Name: "x", Messages: []string{"x must be less than -1."}}}) {
+		t.Fatalf("expected error but got %v instead", results)
+	}
+}